@@ -0,0 +1,9 @@
+drop function IF EXISTS search_videos(p_query text, p_language text, p_limit int, p_offset int, p_segments int);
+
+drop trigger IF EXISTS video_segments_index on video;
+
+drop function IF EXISTS index_video_segments();
+
+DROP TABLE IF EXISTS video_segments;
+
+drop function IF EXISTS language_regconfig(p_language text);
@@ -0,0 +1,127 @@
+create or replace function language_regconfig(p_language text) returns regconfig as
+$$
+begin
+    return case lower(left(p_language, 2))
+        when 'ar' then 'arabic'
+        when 'da' then 'danish'
+        when 'de' then 'german'
+        when 'el' then 'greek'
+        when 'en' then 'english'
+        when 'es' then 'spanish'
+        when 'fi' then 'finnish'
+        when 'fr' then 'french'
+        when 'hu' then 'hungarian'
+        when 'id' then 'indonesian'
+        when 'it' then 'italian'
+        when 'lt' then 'lithuanian'
+        when 'nl' then 'dutch'
+        when 'no' then 'norwegian'
+        when 'pt' then 'portuguese'
+        when 'ro' then 'romanian'
+        when 'ru' then 'russian'
+        when 'sv' then 'swedish'
+        when 'tr' then 'turkish'
+        else 'simple'
+    end::regconfig;
+end;
+$$
+    language plpgsql immutable;
+
+create table IF NOT EXISTS video_segments (
+        id serial primary key,
+        video_id int not null,
+        segment_index int not null,
+        start double precision not null,
+        dur double precision not null,
+        subtitle text not null,
+        config regconfig not null,
+        search_vector tsvector not null,
+        foreign key (video_id) references video (id) on delete cascade
+);
+
+create index IF NOT EXISTS video_segments_video_id_idx on video_segments (video_id);
+create index IF NOT EXISTS video_segments_search_idx on video_segments using gin (search_vector);
+
+create or replace function index_video_segments() returns trigger as
+$$
+begin
+    delete from video_segments where video_id = new.id;
+
+    insert into video_segments (video_id, segment_index, start, dur, subtitle, config, search_vector)
+    select new.id,
+           (t.ordinality - 1)::int,
+           coalesce((t.value ->> 'start')::double precision, 0),
+           coalesce((t.value ->> 'dur')::double precision, 0),
+           coalesce(t.value ->> 'subtitle', ''),
+           language_regconfig(new.language),
+           to_tsvector(language_regconfig(new.language), coalesce(t.value ->> 'subtitle', ''))
+    from jsonb_array_elements(
+            case jsonb_typeof(new.transcription) when 'array' then new.transcription else '[]'::jsonb end
+         ) with ordinality as t(value, ordinality);
+
+    return new;
+end;
+$$
+    language plpgsql;
+
+create trigger video_segments_index
+    after insert or update of transcription, language on video
+    for each row
+execute function index_video_segments();
+
+-- index already stored transcriptions
+update video set transcription = transcription;
+
+create or replace function search_videos(
+    p_query text,
+    p_language text,
+    p_limit int,
+    p_offset int,
+    p_segments int)
+    returns table (
+        id int,
+        video_id text,
+        language text,
+        title text,
+        rank real,
+        segment_index int,
+        start double precision,
+        dur double precision,
+        snippet text
+    ) as
+$$
+    with queries as (
+        select c.config, websearch_to_tsquery(c.config, p_query) as q
+        from (select distinct s.config from video_segments s) c
+    ),
+    hits as (
+        select s.video_id, s.segment_index, s.start, s.dur, s.subtitle, s.config, qs.q,
+               ts_rank(s.search_vector, qs.q) as rank
+        from video_segments s
+        join queries qs on qs.config = s.config
+        where s.search_vector @@ qs.q
+    ),
+    videos as (
+        select h.video_id, sum(h.rank)::real as rank
+        from hits h
+        join video v on v.id = h.video_id
+        where is_text_empty_or_null(p_language) or v.language = p_language
+        group by h.video_id
+        order by sum(h.rank) desc, h.video_id
+        limit p_limit offset p_offset
+    ),
+    ranked as (
+        select h.*, row_number() over (partition by h.video_id order by h.rank desc, h.segment_index) as n
+        from hits h
+        join videos vs on vs.video_id = h.video_id
+    )
+    select v.id, v.video_id, v.language, v.title, vs.rank,
+           r.segment_index, r.start, r.dur,
+           ts_headline(r.config, r.subtitle, r.q, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')
+    from ranked r
+    join videos vs on vs.video_id = r.video_id
+    join video v on v.id = r.video_id
+    where r.n <= p_segments
+    order by vs.rank desc, v.id, r.start;
+$$
+    language sql stable;
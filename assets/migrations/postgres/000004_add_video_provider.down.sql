@@ -0,0 +1,37 @@
+drop procedure IF EXISTS put_video(
+    p_title text,
+    p_description text,
+    p_available_langs text[],
+    p_length_in_seconds text,
+    p_thumbnails jsonb,
+    p_transcription jsonb,
+    p_video_id char(11),
+    p_language char(2),
+    p_provider text
+);
+
+create or replace procedure put_video(
+    p_title text,
+    p_description text,
+    p_available_langs text[],
+    p_length_in_seconds text,
+    p_thumbnails jsonb,
+    p_transcription jsonb,
+    p_video_id char(11),
+    p_language char(2)
+    )
+     as
+$$
+begin
+    insert into video (
+    title, description, available_langs, length_in_seconds, thumbnails, transcription, video_id, language
+    )
+    values (
+    p_title, p_description, p_available_langs, p_length_in_seconds, p_thumbnails, p_transcription, p_video_id, p_language
+    );
+
+end;
+$$
+    language plpgsql;
+
+alter table video drop column IF EXISTS provider;
@@ -0,0 +1,3 @@
+drop function IF EXISTS claim_job();
+
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,37 @@
+create table IF NOT EXISTS jobs (
+        id serial primary key,
+        user_id int not null,
+        video_id text not null,
+        language text not null,
+        state text not null default 'queued'
+            CONSTRAINT valid_state CHECK ( state in ('queued', 'running', 'succeeded', 'failed') ),
+        error text,
+        result_id int,
+        attempts int not null default 0,
+        created_at timestamptz not null default now(),
+        updated_at timestamptz not null default now(),
+        foreign key (user_id) references users (id),
+        foreign key (result_id) references video (id) on delete set null
+);
+
+create index IF NOT EXISTS jobs_queued_idx on jobs (id) where state = 'queued';
+
+create or replace function claim_job()
+    returns setof jobs as
+$$
+begin
+    return query
+        update jobs
+        set state = 'running', attempts = attempts + 1, updated_at = now()
+        where id = (
+            select j.id
+            from jobs j
+            where j.state = 'queued'
+            order by j.id
+            limit 1
+            for update skip locked
+        )
+        returning *;
+end;
+$$
+    language plpgsql;
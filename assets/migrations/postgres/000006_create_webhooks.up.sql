@@ -0,0 +1,28 @@
+create table IF NOT EXISTS webhooks (
+        id serial primary key,
+        user_id int not null,
+        url text not null,
+        secret text not null,
+        events text[] not null,
+        created_at timestamptz not null default now(),
+        foreign key (user_id) references users (id)
+);
+
+create index IF NOT EXISTS webhooks_user_id_idx on webhooks (user_id);
+
+create table IF NOT EXISTS webhook_deliveries (
+        id serial primary key,
+        webhook_id int not null,
+        event text not null,
+        payload jsonb not null,
+        state text not null default 'pending'
+            CONSTRAINT valid_state CHECK ( state in ('pending', 'succeeded', 'failed') ),
+        attempts int not null default 0,
+        response_status int,
+        error text,
+        created_at timestamptz not null default now(),
+        updated_at timestamptz not null default now(),
+        foreign key (webhook_id) references webhooks (id) on delete cascade
+);
+
+create index IF NOT EXISTS webhook_deliveries_webhook_id_idx on webhook_deliveries (webhook_id);
@@ -0,0 +1,10 @@
+create table IF NOT EXISTS summaries (
+        id serial primary key,
+        video_id text not null,
+        language text not null,
+        style text not null,
+        model text not null,
+        summary text not null,
+        created_at timestamptz not null default now(),
+        CONSTRAINT summaries_key UNIQUE (video_id, language, style, model)
+);
@@ -0,0 +1,2 @@
+alter table video drop column IF EXISTS chapters_source;
+alter table video drop column IF EXISTS chapters;
@@ -0,0 +1,2 @@
+alter table video add column IF NOT EXISTS chapters jsonb;
+alter table video add column IF NOT EXISTS chapters_source text;
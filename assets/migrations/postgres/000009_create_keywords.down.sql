@@ -0,0 +1,5 @@
+DROP FUNCTION IF EXISTS refresh_keyword_stats();
+DROP TABLE IF EXISTS keyword_corpus;
+DROP TABLE IF EXISTS keyword_stats;
+DROP TABLE IF EXISTS video_keywords;
+alter table video drop column IF EXISTS keywords_updated_at;
@@ -0,0 +1,57 @@
+alter table video add column IF NOT EXISTS keywords_updated_at timestamptz;
+
+create table IF NOT EXISTS video_keywords (
+        video_id int not null,
+        keyword text not null,
+        score double precision not null,
+        primary key (video_id, keyword),
+        foreign key (video_id) references video (id) on delete cascade
+);
+
+create index IF NOT EXISTS video_keywords_keyword_idx on video_keywords (keyword);
+
+create table IF NOT EXISTS keyword_stats (
+        language text not null,
+        term text not null,
+        df int not null,
+        primary key (language, term)
+);
+
+create table IF NOT EXISTS keyword_corpus (
+        language text primary key,
+        documents int not null,
+        updated_at timestamptz not null default now()
+);
+
+-- refresh_keyword_stats recomputes document frequency of terms of every language.
+-- Terms are split the same way as keywords.Terms does.
+create or replace function refresh_keyword_stats() returns int as
+$$
+declare
+    v_documents int;
+begin
+    lock table keyword_stats, keyword_corpus in exclusive mode;
+
+    delete from keyword_stats;
+    delete from keyword_corpus;
+
+    insert into keyword_stats (language, term, df)
+    select words.language, words.term, count(distinct words.video_id)
+    from (
+        select v.language, s.video_id,
+               btrim(regexp_split_to_table(lower(s.subtitle), '[^[:alnum:]''’]+'), '''’') as term
+        from video_segments s
+        join video v on v.id = s.video_id
+    ) as words
+    where words.term <> ''
+    group by words.language, words.term;
+
+    insert into keyword_corpus (language, documents)
+    select language, count(*) from video group by language;
+
+    select count(*) into v_documents from video;
+
+    return v_documents;
+end;
+$$
+    language plpgsql;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS embedded_videos;
+DROP TABLE IF EXISTS video_embeddings;
@@ -0,0 +1,23 @@
+create table IF NOT EXISTS video_embeddings (
+        id serial primary key,
+        video_id int not null,
+        embedder text not null,
+        window_index int not null,
+        start double precision not null,
+        first_segment int not null,
+        text text not null,
+        vector real[] not null,
+        foreign key (video_id) references video (id) on delete cascade
+);
+
+create index IF NOT EXISTS video_embeddings_embedder_idx on video_embeddings (embedder, id);
+create index IF NOT EXISTS video_embeddings_video_id_idx on video_embeddings (video_id);
+
+-- embedded_videos marks videos indexed by embedder, including videos without transcription
+create table IF NOT EXISTS embedded_videos (
+        video_id int not null,
+        embedder text not null,
+        updated_at timestamptz not null default now(),
+        primary key (video_id, embedder),
+        foreign key (video_id) references video (id) on delete cascade
+);
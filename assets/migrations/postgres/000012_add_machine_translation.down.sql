@@ -0,0 +1,45 @@
+delete from video where machine_translated;
+
+create or replace function refresh_keyword_stats() returns int as
+$$
+declare
+    v_documents int;
+begin
+    lock table keyword_stats, keyword_corpus in exclusive mode;
+
+    delete from keyword_stats;
+    delete from keyword_corpus;
+
+    insert into keyword_stats (language, term, df)
+    select words.language, words.term, count(distinct words.video_id)
+    from (
+        select v.language, s.video_id,
+               btrim(regexp_split_to_table(lower(s.subtitle), '[^[:alnum:]''’]+'), '''’') as term
+        from video_segments s
+        join video v on v.id = s.video_id
+    ) as words
+    where words.term <> ''
+    group by words.language, words.term;
+
+    insert into keyword_corpus (language, documents)
+    select language, count(*) from video group by language;
+
+    select count(*) into v_documents from video;
+
+    return v_documents;
+end;
+$$
+    language plpgsql;
+
+drop trigger IF EXISTS video_segments_index on video;
+
+create trigger video_segments_index
+    after insert or update of transcription, language on video
+    for each row
+execute function index_video_segments();
+
+drop index IF EXISTS video_translation_key;
+
+alter table video drop column IF EXISTS translator;
+alter table video drop column IF EXISTS translated_from;
+alter table video drop column IF EXISTS machine_translated;
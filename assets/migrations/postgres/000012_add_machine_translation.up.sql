@@ -0,0 +1,46 @@
+alter table video add column IF NOT EXISTS machine_translated boolean not null default false;
+alter table video add column IF NOT EXISTS translated_from text;
+alter table video add column IF NOT EXISTS translator text;
+
+create unique index IF NOT EXISTS video_translation_key
+    on video (video_id, language, translated_from, translator) where machine_translated;
+
+-- machine translations aren`t searchable and don`t affect keyword statistics
+drop trigger IF EXISTS video_segments_index on video;
+
+create trigger video_segments_index
+    after insert or update of transcription, language on video
+    for each row
+    when (not new.machine_translated)
+execute function index_video_segments();
+
+create or replace function refresh_keyword_stats() returns int as
+$$
+declare
+    v_documents int;
+begin
+    lock table keyword_stats, keyword_corpus in exclusive mode;
+
+    delete from keyword_stats;
+    delete from keyword_corpus;
+
+    insert into keyword_stats (language, term, df)
+    select words.language, words.term, count(distinct words.video_id)
+    from (
+        select v.language, s.video_id,
+               btrim(regexp_split_to_table(lower(s.subtitle), '[^[:alnum:]''’]+'), '''’') as term
+        from video_segments s
+        join video v on v.id = s.video_id
+    ) as words
+    where words.term <> ''
+    group by words.language, words.term;
+
+    insert into keyword_corpus (language, documents)
+    select language, count(*) from video where not machine_translated group by language;
+
+    select count(*) into v_documents from video where not machine_translated;
+
+    return v_documents;
+end;
+$$
+    language plpgsql;
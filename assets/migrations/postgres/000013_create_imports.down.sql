@@ -0,0 +1,50 @@
+delete from user_videos where video_id in (select id from video where source = 'import');
+delete from video where source = 'import';
+
+create or replace function refresh_keyword_stats() returns int as
+$$
+declare
+    v_documents int;
+begin
+    lock table keyword_stats, keyword_corpus in exclusive mode;
+
+    delete from keyword_stats;
+    delete from keyword_corpus;
+
+    insert into keyword_stats (language, term, df)
+    select words.language, words.term, count(distinct words.video_id)
+    from (
+        select v.language, s.video_id,
+               btrim(regexp_split_to_table(lower(s.subtitle), '[^[:alnum:]''’]+'), '''’') as term
+        from video_segments s
+        join video v on v.id = s.video_id
+    ) as words
+    where words.term <> ''
+    group by words.language, words.term;
+
+    insert into keyword_corpus (language, documents)
+    select language, count(*) from video where not machine_translated group by language;
+
+    select count(*) into v_documents from video where not machine_translated;
+
+    return v_documents;
+end;
+$$
+    language plpgsql;
+
+drop trigger IF EXISTS video_segments_index on video;
+
+create trigger video_segments_index
+    after insert or update of transcription, language on video
+    for each row
+    when (not new.machine_translated)
+execute function index_video_segments();
+
+drop index IF EXISTS video_import_key;
+
+alter table video drop constraint IF EXISTS import_has_owner;
+alter table video drop constraint IF EXISTS video_owner_fkey;
+alter table video drop constraint IF EXISTS valid_source;
+
+alter table video drop column IF EXISTS owner;
+alter table video drop column IF EXISTS source;
@@ -0,0 +1,53 @@
+alter table video add column IF NOT EXISTS source text not null default 'youtube';
+alter table video add column IF NOT EXISTS owner int;
+
+alter table video drop constraint IF EXISTS valid_source;
+alter table video add constraint valid_source check ( source in ('youtube', 'import') );
+
+alter table video drop constraint IF EXISTS video_owner_fkey;
+alter table video add constraint video_owner_fkey foreign key (owner) references users (id) on delete cascade;
+
+alter table video drop constraint IF EXISTS import_has_owner;
+alter table video add constraint import_has_owner check ( source <> 'import' or owner is not null );
+
+create unique index IF NOT EXISTS video_import_key on video (owner, video_id, language) where source = 'import';
+
+-- imported transcripts are private, they aren`t searchable and don`t affect keyword statistics
+drop trigger IF EXISTS video_segments_index on video;
+
+create trigger video_segments_index
+    after insert or update of transcription, language on video
+    for each row
+    when (not new.machine_translated and new.source = 'youtube')
+execute function index_video_segments();
+
+create or replace function refresh_keyword_stats() returns int as
+$$
+declare
+    v_documents int;
+begin
+    lock table keyword_stats, keyword_corpus in exclusive mode;
+
+    delete from keyword_stats;
+    delete from keyword_corpus;
+
+    insert into keyword_stats (language, term, df)
+    select words.language, words.term, count(distinct words.video_id)
+    from (
+        select v.language, s.video_id,
+               btrim(regexp_split_to_table(lower(s.subtitle), '[^[:alnum:]''’]+'), '''’') as term
+        from video_segments s
+        join video v on v.id = s.video_id
+    ) as words
+    where words.term <> ''
+    group by words.language, words.term;
+
+    insert into keyword_corpus (language, documents)
+    select language, count(*) from video where not machine_translated and source = 'youtube' group by language;
+
+    select count(*) into v_documents from video where not machine_translated and source = 'youtube';
+
+    return v_documents;
+end;
+$$
+    language plpgsql;
@@ -0,0 +1,60 @@
+-- captions are plain text, so snippet must not contain markup except highlighting
+create or replace function escape_html(str text) returns text as
+$$
+    select replace(replace(replace(str, '&', '&amp;'), '<', '&lt;'), '>', '&gt;');
+$$
+    language sql immutable;
+
+create or replace function search_videos(
+    p_query text,
+    p_language text,
+    p_limit int,
+    p_offset int,
+    p_segments int)
+    returns table (
+        id int,
+        video_id text,
+        language text,
+        title text,
+        rank real,
+        segment_index int,
+        start double precision,
+        dur double precision,
+        snippet text
+    ) as
+$$
+    with queries as (
+        select c.config, websearch_to_tsquery(c.config, p_query) as q
+        from (select distinct s.config from video_segments s) c
+    ),
+    hits as (
+        select s.video_id, s.segment_index, s.start, s.dur, s.subtitle, s.config, qs.q,
+               ts_rank(s.search_vector, qs.q) as rank
+        from video_segments s
+        join queries qs on qs.config = s.config
+        where s.search_vector @@ qs.q
+    ),
+    videos as (
+        select h.video_id, sum(h.rank)::real as rank
+        from hits h
+        join video v on v.id = h.video_id
+        where is_text_empty_or_null(p_language) or v.language = p_language
+        group by h.video_id
+        order by sum(h.rank) desc, h.video_id
+        limit p_limit offset p_offset
+    ),
+    ranked as (
+        select h.*, row_number() over (partition by h.video_id order by h.rank desc, h.segment_index) as n
+        from hits h
+        join videos vs on vs.video_id = h.video_id
+    )
+    select v.id, v.video_id, v.language, v.title, vs.rank,
+           r.segment_index, r.start, r.dur,
+           ts_headline(r.config, escape_html(r.subtitle), r.q, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')
+    from ranked r
+    join videos vs on vs.video_id = r.video_id
+    join video v on v.id = r.video_id
+    where r.n <= p_segments
+    order by vs.rank desc, v.id, r.start;
+$$
+    language sql stable;
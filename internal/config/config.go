@@ -1,12 +1,19 @@
 package config
 
 import (
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func Route() RouteConfiguration {
+	debugVars, _ := strconv.ParseBool(os.Getenv("DEBUG_VARS"))
+
 	return RouteConfiguration{
-		Port: os.Getenv("APP_PORT"),
+		Port:      os.Getenv("APP_PORT"),
+		DebugVars: debugVars,
 	}
 }
 
@@ -20,8 +27,261 @@ func DB() DBConfiguration {
 	}
 }
 
+// Finder uses `rapidapi` provider if TRANSCRIPT_PROVIDERS is empty
+func Finder() FinderConfiguration {
+	providers := []string{"rapidapi"}
+	if env := os.Getenv("TRANSCRIPT_PROVIDERS"); env != "" {
+		providers = strings.Split(strings.ReplaceAll(env, " ", ""), ",")
+	}
+
+	retries, err := strconv.Atoi(os.Getenv("FINDER_RETRIES"))
+	if err != nil || retries < 0 {
+		retries = 2
+	}
+
+	interval, err := time.ParseDuration(os.Getenv("FINDER_RETRY_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+
+	maxInterval, err := time.ParseDuration(os.Getenv("FINDER_MAX_RETRY_INTERVAL"))
+	if err != nil || maxInterval <= 0 {
+		maxInterval = 10 * time.Second
+	}
+
+	failures, err := strconv.Atoi(os.Getenv("FINDER_BREAKER_FAILURES"))
+	if err != nil {
+		failures = 5
+	}
+
+	cooldown, err := time.ParseDuration(os.Getenv("FINDER_BREAKER_COOLDOWN"))
+	if err != nil || cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+
+	rate, _ := strconv.ParseFloat(os.Getenv("FINDER_RATE_LIMIT"), 64)
+
+	burst, err := strconv.Atoi(os.Getenv("FINDER_RATE_BURST"))
+	if err != nil || burst < 1 {
+		burst = 1
+	}
+
+	return FinderConfiguration{
+		Providers:        providers,
+		SubtitlesDir:     os.Getenv("SUBTITLES_DIR"),
+		YouTubeURL:       os.Getenv("YOUTUBE_BASE_URL"),
+		Retries:          retries,
+		RetryInterval:    interval,
+		MaxRetryInterval: maxInterval,
+		BreakerFailures:  failures,
+		BreakerCooldown:  cooldown,
+		RateLimit:        rate,
+		RateBurst:        burst,
+	}
+}
+
+// Jobs uses 4 workers and 5s poll interval by default
+func Jobs() JobsConfiguration {
+	workers, err := strconv.Atoi(os.Getenv("JOB_WORKERS"))
+	if err != nil || workers < 1 {
+		workers = 4
+	}
+
+	poll, err := time.ParseDuration(os.Getenv("JOB_POLL_INTERVAL"))
+	if err != nil || poll <= 0 {
+		poll = 5 * time.Second
+	}
+
+	return JobsConfiguration{
+		Workers:      workers,
+		PollInterval: poll,
+	}
+}
+
+// Keywords refreshes IDF statistics hourly when the number of videos grows by 10% and tags 50 untagged videos
+func Keywords() KeywordsConfiguration {
+	interval, err := time.ParseDuration(os.Getenv("KEYWORDS_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = time.Hour
+	}
+
+	batch, err := strconv.Atoi(os.Getenv("KEYWORDS_BATCH"))
+	if err != nil || batch < 1 {
+		batch = 50
+	}
+
+	growth, err := strconv.ParseFloat(os.Getenv("KEYWORDS_GROWTH"), 64)
+	if err != nil || growth < 0 {
+		growth = 0.1
+	}
+
+	return KeywordsConfiguration{
+		Interval: interval,
+		Batch:    batch,
+		Growth:   growth,
+	}
+}
+
+// Embedding uses local hashing embedder by default. `http` embedder uses OpenAI-compatible API.
+func Embedding() EmbeddingConfiguration {
+	embedder := strings.ToLower(strings.TrimSpace(os.Getenv("EMBEDDER")))
+	if embedder == "" {
+		embedder = "hashing"
+	}
+
+	model := os.Getenv("EMBEDDING_MODEL")
+	if model == "" {
+		model = "text-embedding-3-small"
+	}
+
+	dimensions, err := strconv.Atoi(os.Getenv("EMBEDDING_DIMENSIONS"))
+	if err != nil || dimensions < 1 {
+		dimensions = 256
+		if embedder == "http" {
+			dimensions = 1536
+		}
+	}
+
+	interval, err := time.ParseDuration(os.Getenv("EMBEDDING_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = time.Minute
+	}
+
+	batch, err := strconv.Atoi(os.Getenv("EMBEDDING_BATCH"))
+	if err != nil || batch < 1 {
+		batch = 20
+	}
+
+	return EmbeddingConfiguration{
+		Embedder:   embedder,
+		BaseURL:    os.Getenv("EMBEDDING_BASE_URL"),
+		APIKey:     os.Getenv("EMBEDDING_API_KEY"),
+		Model:      model,
+		Dimensions: dimensions,
+		Interval:   interval,
+		Batch:      batch,
+	}
+}
+
+// Batch uses 4 parallel fetches and 50 videos per request by default
+func Batch() BatchConfiguration {
+	concurrency, err := strconv.Atoi(os.Getenv("BATCH_CONCURRENCY"))
+	if err != nil || concurrency < 1 {
+		concurrency = 4
+	}
+
+	limit, err := strconv.Atoi(os.Getenv("BATCH_MAX_VIDEOS"))
+	if err != nil || limit < 1 {
+		limit = 50
+	}
+
+	return BatchConfiguration{
+		Concurrency: concurrency,
+		MaxVideos:   limit,
+	}
+}
+
+func Playlist() PlaylistConfiguration {
+	return PlaylistConfiguration{
+		APIKey:  os.Getenv("YOUTUBE_API_KEY"),
+		BaseURL: os.Getenv("YOUTUBE_API_URL"),
+	}
+}
+
+// Webhook uses 2 workers, 1s initial retry interval and 15m of retries by default.
+// Pending deliveries not updated for 5m are enqueued again every minute.
+func Webhook() WebhookConfiguration {
+	workers, err := strconv.Atoi(os.Getenv("WEBHOOK_WORKERS"))
+	if err != nil || workers < 1 {
+		workers = 2
+	}
+
+	initial, err := time.ParseDuration(os.Getenv("WEBHOOK_INITIAL_INTERVAL"))
+	if err != nil || initial <= 0 {
+		initial = time.Second
+	}
+
+	elapsed, err := time.ParseDuration(os.Getenv("WEBHOOK_MAX_ELAPSED_TIME"))
+	if err != nil || elapsed <= 0 {
+		elapsed = 15 * time.Minute
+	}
+
+	sweep, err := time.ParseDuration(os.Getenv("WEBHOOK_SWEEP_INTERVAL"))
+	if err != nil || sweep <= 0 {
+		sweep = time.Minute
+	}
+
+	stale, err := time.ParseDuration(os.Getenv("WEBHOOK_STALE_AFTER"))
+	if err != nil || stale <= 0 {
+		stale = 5 * time.Minute
+	}
+
+	var allowed []*net.IPNet
+	for _, network := range strings.Split(os.Getenv("WEBHOOK_ALLOWED_NETWORKS"), ",") {
+		network = strings.TrimSpace(network)
+		if network == "" {
+			continue
+		}
+		if ip := net.ParseIP(network); ip != nil {
+			allowed = append(allowed, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(network); err == nil {
+			allowed = append(allowed, ipNet)
+		}
+	}
+
+	return WebhookConfiguration{
+		Workers:         workers,
+		InitialInterval: initial,
+		MaxElapsedTime:  elapsed,
+		SweepInterval:   sweep,
+		StaleAfter:      stale,
+		AllowedNetworks: allowed,
+	}
+}
+
+// LLM uses gpt-3.5-turbo model by default.
+// Zero chunk settings mean defaults of the model.
+func LLM() LLMConfiguration {
+	model := os.Getenv("OPENAI_MODEL")
+	if model == "" {
+		model = "gpt-3.5-turbo"
+	}
+
+	chunkTokens, _ := strconv.Atoi(os.Getenv("OPENAI_CHUNK_TOKENS"))
+	chunkOverlap, _ := strconv.Atoi(os.Getenv("OPENAI_CHUNK_OVERLAP"))
+	concurrency, _ := strconv.Atoi(os.Getenv("OPENAI_CONCURRENCY"))
+
+	return LLMConfiguration{
+		APIKey:       os.Getenv("OPENAI_API_KEY"),
+		BaseURL:      os.Getenv("OPENAI_BASE_URL"),
+		Model:        model,
+		ChunkTokens:  chunkTokens,
+		ChunkOverlap: chunkOverlap,
+		Concurrency:  concurrency,
+	}
+}
+
+// Translation is disabled if TRANSLATOR isn`t set
+func Translation() TranslationConfiguration {
+	batch, err := strconv.Atoi(os.Getenv("TRANSLATE_BATCH"))
+	if err != nil || batch < 1 {
+		batch = 50
+	}
+
+	return TranslationConfiguration{
+		Translator: strings.ToLower(strings.TrimSpace(os.Getenv("TRANSLATOR"))),
+		BaseURL:    os.Getenv("TRANSLATE_BASE_URL"),
+		APIKey:     os.Getenv("TRANSLATE_API_KEY"),
+		Batch:      batch,
+	}
+}
+
 type RouteConfiguration struct {
 	Port string `env:"APP_PORT"`
+	// DebugVars exposes runtime and transcript providers metrics in `/debug/vars`
+	DebugVars bool `env:"DEBUG_VARS"`
 }
 
 type DBConfiguration struct {
@@ -31,3 +291,88 @@ type DBConfiguration struct {
 	Port     string `env:"DB_PORT"`
 	Database string `env:"DB_DATABASE"`
 }
+
+type FinderConfiguration struct {
+	// Providers in priority order
+	Providers    []string `env:"TRANSCRIPT_PROVIDERS"`
+	SubtitlesDir string   `env:"SUBTITLES_DIR"`
+	// YouTubeURL is the base URL of `timedtext` provider
+	YouTubeURL string `env:"YOUTUBE_BASE_URL"`
+	// Retries of failed provider request after the first attempt
+	Retries          int           `env:"FINDER_RETRIES"`
+	RetryInterval    time.Duration `env:"FINDER_RETRY_INTERVAL"`
+	MaxRetryInterval time.Duration `env:"FINDER_MAX_RETRY_INTERVAL"`
+	// BreakerFailures in a row open circuit breaker for BreakerCooldown, zero disables breaker
+	BreakerFailures int           `env:"FINDER_BREAKER_FAILURES"`
+	BreakerCooldown time.Duration `env:"FINDER_BREAKER_COOLDOWN"`
+	// RateLimit is requests per second of every provider, zero disables limiter
+	RateLimit float64 `env:"FINDER_RATE_LIMIT"`
+	RateBurst int     `env:"FINDER_RATE_BURST"`
+}
+
+type JobsConfiguration struct {
+	Workers      int           `env:"JOB_WORKERS"`
+	PollInterval time.Duration `env:"JOB_POLL_INTERVAL"`
+}
+
+type BatchConfiguration struct {
+	Concurrency int `env:"BATCH_CONCURRENCY"`
+	MaxVideos   int `env:"BATCH_MAX_VIDEOS"`
+}
+
+type PlaylistConfiguration struct {
+	APIKey  string `env:"YOUTUBE_API_KEY"`
+	BaseURL string `env:"YOUTUBE_API_URL"`
+}
+
+type WebhookConfiguration struct {
+	Workers         int           `env:"WEBHOOK_WORKERS"`
+	InitialInterval time.Duration `env:"WEBHOOK_INITIAL_INTERVAL"`
+	MaxElapsedTime  time.Duration `env:"WEBHOOK_MAX_ELAPSED_TIME"`
+	// SweepInterval of enqueueing pending deliveries which weren`t updated for StaleAfter
+	SweepInterval time.Duration `env:"WEBHOOK_SWEEP_INTERVAL"`
+	StaleAfter    time.Duration `env:"WEBHOOK_STALE_AFTER"`
+	// AllowedNetworks are loopback, link-local or private networks webhooks can be delivered to
+	AllowedNetworks []*net.IPNet `env:"WEBHOOK_ALLOWED_NETWORKS"`
+}
+
+type LLMConfiguration struct {
+	APIKey string `env:"OPENAI_API_KEY"`
+	// BaseURL of OpenAI-compatible API, e.g. local stand-in server
+	BaseURL string `env:"OPENAI_BASE_URL"`
+	Model   string `env:"OPENAI_MODEL"`
+	// ChunkTokens is the largest part of transcript in a single prompt
+	ChunkTokens  int `env:"OPENAI_CHUNK_TOKENS"`
+	ChunkOverlap int `env:"OPENAI_CHUNK_OVERLAP"`
+	Concurrency  int `env:"OPENAI_CONCURRENCY"`
+}
+
+type KeywordsConfiguration struct {
+	Interval time.Duration `env:"KEYWORDS_INTERVAL"`
+	// Batch of untagged videos processed every interval
+	Batch int `env:"KEYWORDS_BATCH"`
+	// Growth of the number of videos that triggers statistics refresh, fraction
+	Growth float64 `env:"KEYWORDS_GROWTH"`
+}
+
+type EmbeddingConfiguration struct {
+	// Embedder is `hashing` or `http`
+	Embedder   string `env:"EMBEDDER"`
+	BaseURL    string `env:"EMBEDDING_BASE_URL"`
+	APIKey     string `env:"EMBEDDING_API_KEY"`
+	Model      string `env:"EMBEDDING_MODEL"`
+	Dimensions int    `env:"EMBEDDING_DIMENSIONS"`
+	// Interval of embedding stored videos
+	Interval time.Duration `env:"EMBEDDING_INTERVAL"`
+	// Batch of stored videos embedded every interval
+	Batch int `env:"EMBEDDING_BATCH"`
+}
+
+type TranslationConfiguration struct {
+	// Translator is `libretranslate` or `stub`
+	Translator string `env:"TRANSLATOR"`
+	BaseURL    string `env:"TRANSLATE_BASE_URL"`
+	APIKey     string `env:"TRANSLATE_API_KEY"`
+	// Batch is the number of segments translated by one request
+	Batch int `env:"TRANSLATE_BATCH"`
+}
@@ -0,0 +1,33 @@
+package models
+
+// AskRequest is a question about video transcription
+type AskRequest struct {
+	Question string `json:"question" validate:"required,max=500"`
+	Language string `json:"lang"`
+	// Mode is `answer` or `retrieval`
+	Mode  string `json:"mode"`
+	Limit int    `json:"limit" validate:"min=0,max=20"`
+}
+
+// Citation is a transcript passage with the link to its start
+type Citation struct {
+	Start        float64 `json:"start"`
+	End          float64 `json:"end"`
+	FirstSegment int     `json:"firstSegment"` //nolint:tagliatelle
+	LastSegment  int     `json:"lastSegment"`  //nolint:tagliatelle
+	Text         string  `json:"text"`
+	Score        float64 `json:"score"`
+	Link         string  `json:"link"`
+}
+
+// Answer contains cited passages if model answered the question and all retrieved passages
+type Answer struct {
+	VideoID   string     `json:"videoId"` //nolint:tagliatelle
+	Language  string     `json:"language"`
+	Question  string     `json:"question"`
+	Mode      string     `json:"mode"`
+	Answer    string     `json:"answer,omitempty"`
+	Model     string     `json:"model,omitempty"`
+	Citations []Citation `json:"citations"`
+	Passages  []Citation `json:"passages"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// BilingualPair is a primary track cue with the text of secondary track aligned to it by time
+type BilingualPair struct {
+	Start     float64 `json:"start"`
+	End       float64 `json:"end"`
+	Primary   string  `json:"primary"`
+	Secondary string  `json:"secondary"`
+}
+
+// BilingualResult is a response of video bilingual endpoint
+type BilingualResult struct {
+	VideoID   string          `json:"videoId"` //nolint:tagliatelle
+	Title     string          `json:"title"`
+	Primary   string          `json:"primary"`
+	Secondary string          `json:"secondary"`
+	Pairs     []BilingualPair `json:"pairs"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// Chapter is a titled range of video transcription. Segments are indexes of the first and the last transcription segment.
+type Chapter struct {
+	Title        string  `json:"title"`
+	Start        float64 `json:"start"`
+	End          float64 `json:"end"`
+	FirstSegment int     `json:"firstSegment"` //nolint:tagliatelle
+	LastSegment  int     `json:"lastSegment"`  //nolint:tagliatelle
+}
+
+// ChaptersResult is a response of video chapters endpoint
+type ChaptersResult struct {
+	VideoID  string    `json:"videoId"` //nolint:tagliatelle
+	Language string    `json:"language"`
+	Source   string    `json:"source"`
+	Chapters []Chapter `json:"chapters"`
+}
@@ -0,0 +1,33 @@
+package models
+
+// Embedding is a vector of the transcript window
+type Embedding struct {
+	ID           int
+	VideoID      int
+	YouTubeID    string
+	Language     string
+	Title        string
+	Window       int
+	Start        float64
+	FirstSegment int
+	Text         string
+	Vector       []float32
+}
+
+// SemanticPassage is a transcript window similar to the query
+type SemanticPassage struct {
+	Start        float64 `json:"start"`
+	FirstSegment int     `json:"firstSegment"` //nolint:tagliatelle
+	Text         string  `json:"text"`
+	Score        float32 `json:"score"`
+	Link         string  `json:"link"`
+}
+
+// SemanticResult is a video similar to the query or to another video
+type SemanticResult struct {
+	VideoID  string            `json:"videoId"` //nolint:tagliatelle
+	Language string            `json:"language"`
+	Title    string            `json:"title"`
+	Score    float32           `json:"score"`
+	Passages []SemanticPassage `json:"passages,omitempty"`
+}
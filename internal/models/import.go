@@ -0,0 +1,15 @@
+package models
+
+const (
+	SourceYouTube = "youtube"
+	SourceImport  = "import"
+)
+
+// ImportResult is a response of transcript import endpoint
+type ImportResult struct {
+	VideoID  string `json:"videoId"` //nolint:tagliatelle
+	Language string `json:"language"`
+	Title    string `json:"title"`
+	Source   string `json:"source"`
+	Segments int    `json:"segments"`
+}
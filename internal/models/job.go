@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type JobState string
+
+const (
+	JobQueued    JobState = "queued"
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+)
+
+// Job is an asynchronous fetch of the video transcription
+type Job struct {
+	ID       int      `json:"id"`
+	UserID   int      `json:"-"`
+	VideoID  string   `json:"videoId"` //nolint:tagliatelle
+	Language string   `json:"language"`
+	State    JobState `json:"state"`
+	Error    string   `json:"error,omitempty"`
+	// ResultID is the id of stored video, -1 until job succeeded
+	ResultID  int       `json:"-"`
+	Result    string    `json:"result,omitempty"`
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"` //nolint:tagliatelle
+	UpdatedAt time.Time `json:"updatedAt"` //nolint:tagliatelle
+}
@@ -0,0 +1,13 @@
+package models
+
+type Keyword struct {
+	Keyword string  `json:"keyword"`
+	Score   float64 `json:"score"`
+}
+
+// KeywordsResult is a response of video keywords endpoint
+type KeywordsResult struct {
+	VideoID  string    `json:"videoId"` //nolint:tagliatelle
+	Language string    `json:"language"`
+	Keywords []Keyword `json:"keywords"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// LanguagesResult is a response of video endpoint for several languages.
+// Transcriptions and Errors are keyed by language.
+type LanguagesResult struct {
+	VideoID        string                     `json:"videoId"` //nolint:tagliatelle
+	Title          string                     `json:"title"`
+	AvailableLangs []string                   `json:"availableLangs"` //nolint:tagliatelle
+	Transcriptions map[string][]Transcription `json:"transcriptions"`
+	Errors         map[string]string          `json:"errors"`
+}
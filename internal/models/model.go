@@ -13,6 +13,16 @@ type YTVideo struct {
 	LengthInSeconds string          `json:"lengthInSeconds"` //nolint:tagliatelle
 	Thumbnails      []Thumbnails    `json:"thumbnails"`
 	Transcription   []Transcription `json:"transcription"`
+	Provider        string          `json:"provider,omitempty"`
+	Chapters        []Chapter       `json:"chapters,omitempty"`
+	ChaptersSource  string          `json:"chaptersSource,omitempty"` //nolint:tagliatelle
+	// MachineTranslated videos are cached apart from original captions
+	MachineTranslated bool   `json:"machineTranslated,omitempty"` //nolint:tagliatelle
+	TranslatedFrom    string `json:"translatedFrom,omitempty"`    //nolint:tagliatelle
+	// Source is SourceImport for transcripts uploaded by user, empty for YouTube videos
+	Source string `json:"source,omitempty"`
+	// Fetched is set if video was fetched from provider rather than read from repository
+	Fetched bool `json:"-"`
 }
 
 type Thumbnails struct {
@@ -67,3 +77,26 @@ type VideoRequest struct {
 	VideoID  string `json:"v" validate:"len=11,ascii"`
 	Language string `json:"lang" validate:"bcp47_language_tag"`
 }
+
+// BatchRequest accepts video ids or URLs and playlist id or URL
+type BatchRequest struct {
+	Videos   []string `json:"videos"`
+	Playlist string   `json:"playlist"`
+	// PlaylistOffset skips playlist videos returned by previous requests
+	PlaylistOffset int    `json:"playlistOffset"` //nolint:tagliatelle
+	Language       string `json:"lang"`
+}
+
+// BatchItem is a result for single video of BatchRequest
+type BatchItem struct {
+	Input   string   `json:"input"`
+	VideoID string   `json:"videoId,omitempty"` //nolint:tagliatelle
+	Video   *YTVideo `json:"video,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// VideoURLRequest accepts any form of YouTube video URL
+type VideoURLRequest struct {
+	URL      string `json:"url"`
+	Language string `json:"lang"`
+}
@@ -0,0 +1,42 @@
+package models
+
+type SearchRequest struct {
+	Query    string `json:"q" validate:"required"`
+	Language string `json:"lang" validate:"omitempty,bcp47_language_tag"`
+	Limit    int    `json:"limit" validate:"min=1,max=100"`
+	Offset   int    `json:"offset" validate:"min=0"`
+}
+
+// SearchResult is a video matched by full-text search with its best matching segments
+type SearchResult struct {
+	VideoID  string          `json:"videoId"` //nolint:tagliatelle
+	Language string          `json:"language"`
+	Title    string          `json:"title"`
+	Rank     float32         `json:"rank"`
+	Segments []SearchSegment `json:"segments"`
+}
+
+type SearchSegment struct {
+	Index   int     `json:"index"`
+	Start   float64 `json:"start"`
+	Dur     float64 `json:"dur"`
+	Snippet string  `json:"snippet"`
+}
+
+// TranscriptHit is a segment of a single video transcription matched by query
+type TranscriptHit struct {
+	Index   int             `json:"index"`
+	Start   float64         `json:"start"`
+	Segment Transcription   `json:"segment"`
+	Before  []Transcription `json:"before"`
+	After   []Transcription `json:"after"`
+	Link    string          `json:"link"`
+}
+
+type TranscriptSearchResult struct {
+	VideoID  string          `json:"videoId"` //nolint:tagliatelle
+	Language string          `json:"language"`
+	Query    string          `json:"query"`
+	Mode     string          `json:"mode"`
+	Hits     []TranscriptHit `json:"hits"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type Summary struct {
+	VideoID   string    `json:"videoId"` //nolint:tagliatelle
+	Language  string    `json:"language"`
+	Style     string    `json:"style"`
+	Model     string    `json:"model"`
+	Summary   string    `json:"summary"`
+	CreatedAt time.Time `json:"createdAt"` //nolint:tagliatelle
+}
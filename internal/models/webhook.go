@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventVideoCompleted = "video.completed"
+	EventVideoFailed    = "video.failed"
+)
+
+type DeliveryState string
+
+const (
+	DeliveryPending   DeliveryState = "pending"
+	DeliverySucceeded DeliveryState = "succeeded"
+	DeliveryFailed    DeliveryState = "failed"
+)
+
+type Webhook struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"-"`
+	URL    string `json:"url" validate:"required,url,startswith=http"`
+	// Secret is returned only on webhook creation
+	Secret    string    `json:"secret,omitempty" validate:"omitempty,min=16"`
+	Events    []string  `json:"events" validate:"required,min=1,dive,oneof=video.completed video.failed"`
+	CreatedAt time.Time `json:"createdAt"` //nolint:tagliatelle
+}
+
+type WebhookDelivery struct {
+	ID             int             `json:"id"`
+	WebhookID      int             `json:"webhookId"` //nolint:tagliatelle
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	State          DeliveryState   `json:"state"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"responseStatus,omitempty"` //nolint:tagliatelle
+	Error          string          `json:"error,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"` //nolint:tagliatelle
+	UpdatedAt      time.Time       `json:"updatedAt"` //nolint:tagliatelle
+}
+
+// VideoEvent is the data of video.completed and video.failed events
+type VideoEvent struct {
+	JobID    int    `json:"jobId,omitempty"` //nolint:tagliatelle
+	VideoID  string `json:"videoId"`         //nolint:tagliatelle
+	Language string `json:"language"`
+	Title    string `json:"title,omitempty"`
+	Result   string `json:"result,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
@@ -0,0 +1,113 @@
+package routes
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"transcribify/internal/models"
+	"transcribify/internal/routes/middlewares"
+	"transcribify/pkg/captions"
+	"transcribify/pkg/finders"
+	"transcribify/pkg/language"
+)
+
+// GetVideoBilingual Handle GET request for two languages of the video aligned by time.
+// Both languages are fetched in parallel and added to user history.
+func (route *Route) GetVideoBilingual(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uid := GetSubFromCtx(ctx)
+	if uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	var (
+		id        = chi.URLParam(r, "id")
+		primary   = models.VideoRequest{VideoID: id, Language: r.URL.Query().Get("primary")}
+		secondary = models.VideoRequest{VideoID: id, Language: r.URL.Query().Get("secondary")}
+	)
+
+	for _, vr := range []models.VideoRequest{primary, secondary} {
+		if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+			w.WriteHeader(http.StatusConflict)
+			route.logger.Info("Invalid video request",
+				zap.Any("video request", vr), zap.Error(err), zap.Bool("valid", valid))
+
+			return
+		}
+	}
+
+	if language.Equal(primary.Language, secondary.Language) {
+		renderError(w, r, http.StatusBadRequest, "primary and secondary languages are the same")
+
+		return
+	}
+
+	format, err := negotiateFormat(r)
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		route.logger.Info("Unsupported response format", zap.Error(err))
+
+		return
+	}
+
+	results := finders.FindLanguages(ctx, route.service.Finder, id, []string{primary.Language, secondary.Language}, 2)
+	for _, res := range results {
+		route.notifyVideo(ctx, uid, res.Request, res.Video, res.Err)
+		if res.Err != nil {
+			route.renderFindError(w, r, res.Err)
+
+			return
+		}
+
+		err = route.repository.User.PutUserVideo(ctx, uid, res.Video.Id)
+		if err != nil {
+			route.logger.Info("Failed to put user video",
+				zap.Error(err), zap.Int("uid", uid), zap.Int("video.Id", res.Video.Id))
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	result := models.BilingualResult{
+		VideoID:   id,
+		Title:     results[0].Video.Title,
+		Primary:   primary.Language,
+		Secondary: secondary.Language,
+		Pairs:     captions.Align(results[0].Video.Transcription, results[1].Video.Transcription),
+	}
+
+	if format == JSON {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, result)
+
+		return
+	}
+
+	f, err := captions.ParseFormat(format)
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		route.logger.Info("Unsupported response format", zap.Error(err))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", f.ContentType())
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="%s.%s-%s%s"`, id, primary.Language, secondary.Language, f.Extension()))
+	w.WriteHeader(http.StatusOK)
+
+	timestamps, _ := strconv.ParseBool(r.URL.Query().Get("timestamps"))
+
+	err = captions.RenderBilingual(w, f, result.Pairs, captions.Options{Timestamps: timestamps})
+	if err != nil {
+		route.logger.Info("Failed to render video", zap.Error(err), zap.String("format", format))
+	}
+}
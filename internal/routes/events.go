@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"net/http"
+	"sync"
+	"transcribify/internal/models"
+	"transcribify/internal/routes/middlewares"
+	"transcribify/pkg/finders"
+	"transcribify/pkg/jobs"
+)
+
+// sse writes Server-Sent Events. Safe for concurrent use.
+type sse struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+	id      int
+}
+
+func newSSE(w http.ResponseWriter) (*sse, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, false
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// disables response buffering of nginx
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &sse{w: w, flusher: flusher}, true
+}
+
+func (s *sse) send(event string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.id++
+	_, err = fmt.Fprintf(s.w, "id: %d\nevent: %s\ndata: %s\n\n", s.id, event, raw)
+	if err != nil {
+		return err
+	}
+	s.flusher.Flush()
+
+	return nil
+}
+
+// GetVideoEvents Handle GET request for video with Server-Sent Events stream of fetching progress.
+// Stream ends with `done` event with video ids or `error` event.
+func (route *Route) GetVideoEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uid := GetSubFromCtx(ctx)
+	if uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	vr, _, err := parseVideoRequest(r)
+	if err != nil {
+		renderError(w, r, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+		w.WriteHeader(http.StatusConflict)
+		route.logger.Info("Invalid video request",
+			zap.Any("video request", vr), zap.Error(err), zap.Bool("valid", valid))
+
+		return
+	}
+
+	stream, ok := newSSE(w)
+	if !ok {
+		w.WriteHeader(http.StatusNotImplemented)
+		route.logger.Info("Streaming is not supported")
+
+		return
+	}
+
+	progress := func(p finders.Progress) {
+		if err := stream.send("progress", p); err != nil {
+			route.logger.Info("Failed to send event", zap.Error(err))
+		}
+	}
+	ctx = finders.WithProgress(ctx, progress)
+
+	video, err := route.service.Finder.Find(ctx, vr)
+	if err == nil {
+		finders.Report(ctx, finders.Progress{Stage: finders.StagePostProcess, Status: finders.StatusStarted})
+
+		err = route.repository.User.PutUserVideo(ctx, uid, video.Id)
+		if err != nil {
+			finders.Report(ctx, finders.Progress{
+				Stage: finders.StagePostProcess, Status: finders.StatusFailed,
+				Code: finders.CodeInternal, Error: "failed to save video in user history",
+			})
+		} else {
+			finders.Report(ctx, finders.Progress{Stage: finders.StagePostProcess, Status: finders.StatusDone})
+		}
+	}
+	route.notifyVideo(ctx, uid, vr, video, err)
+
+	if err != nil {
+		route.logFindError(err)
+		code, message := finders.Describe(err)
+		_ = stream.send("error", map[string]string{"error": message, "code": string(code)})
+
+		return
+	}
+
+	_ = stream.send("done", models.VideoEvent{
+		VideoID:  vr.VideoID,
+		Language: vr.Language,
+		Title:    video.Title,
+		Result:   jobs.ResultLink(vr.VideoID, vr.Language),
+	})
+}
@@ -0,0 +1,165 @@
+package routes
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"transcribify/internal/models"
+	"transcribify/internal/routes/middlewares"
+	"transcribify/pkg/finders"
+	"transcribify/pkg/language"
+	"transcribify/pkg/subtitles"
+)
+
+// MaxImportSize is the largest accepted subtitle file
+const MaxImportSize = 10 << 20
+
+// ImportTranscript Handle POST request with subtitle file in `file` field of multipart form or in request body.
+// Transcript is owned by the user and served by video endpoint. New video id is generated if `id` isn`t set.
+func (route *Route) ImportTranscript(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uid := GetSubFromCtx(ctx)
+	if uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	name, data, err := readImport(w, r)
+	if err != nil {
+		renderError(w, r, http.StatusBadRequest, err.Error())
+		route.logger.Info("Failed to read subtitle file", zap.Error(err))
+
+		return
+	}
+
+	format, err := subtitles.Detect(name, data)
+	if f := r.FormValue("format"); f != "" {
+		format, err = subtitles.ParseFormat(f)
+	}
+	if err != nil {
+		renderError(w, r, http.StatusUnsupportedMediaType, err.Error())
+
+		return
+	}
+
+	vr := models.VideoRequest{VideoID: r.FormValue("id"), Language: r.FormValue("lang")}
+	if vr.VideoID == "" {
+		vr.VideoID = newImportID()
+	}
+
+	if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+		w.WriteHeader(http.StatusConflict)
+		route.logger.Info("Invalid video request",
+			zap.Any("video request", vr), zap.Error(err), zap.Bool("valid", valid))
+
+		return
+	}
+
+	transcription, err := subtitles.Parse(format, data)
+	if err != nil {
+		renderError(w, r, http.StatusUnprocessableEntity, err.Error())
+
+		return
+	}
+
+	last := transcription[len(transcription)-1]
+	video := &models.YTVideo{
+		Title:           r.FormValue("title"),
+		Description:     r.FormValue("description"),
+		AvailableLangs:  []string{vr.Language},
+		LengthInSeconds: strconv.Itoa(int(last.Start + last.Dur)),
+		Thumbnails:      []models.Thumbnails{},
+		Transcription:   transcription,
+		Source:          models.SourceImport,
+	}
+	if video.Title == "" {
+		video.Title = strings.TrimSuffix(name, "."+string(format))
+	}
+
+	video.Id, err = route.repository.Import.CreateImport(ctx, uid, vr, video)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to store imported transcript", zap.Error(err), zap.Int("uid", uid))
+
+		return
+	}
+
+	err = route.repository.User.PutUserVideo(ctx, uid, video.Id)
+	if err != nil {
+		route.logger.Info("Failed to put user video",
+			zap.Error(err), zap.Int("uid", uid), zap.Int("video.Id", video.Id))
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/video/%s?lang=%s", vr.VideoID, vr.Language))
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, models.ImportResult{
+		VideoID:  vr.VideoID,
+		Language: vr.Language,
+		Title:    video.Title,
+		Source:   video.Source,
+		Segments: len(transcription),
+	})
+}
+
+// readImport returns file name and content of multipart `file` field or request body
+func readImport(w http.ResponseWriter, r *http.Request) (string, []byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxImportSize)
+
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		data, err := io.ReadAll(r.Body)
+
+		return "", data, err
+	}
+
+	if err := r.ParseMultipartForm(MaxImportSize); err != nil {
+		return "", nil, err
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+
+	return header.Filename, data, err
+}
+
+// newImportID generates random id in the alphabet of YouTube video ids
+func newImportID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(id)[:11]
+}
+
+// findVideo serves transcript imported by the user, otherwise negotiates YouTube video language
+func (route *Route) findVideo(ctx context.Context, uid int, vr models.VideoRequest, preferences []string, translate bool) (*models.YTVideo, finders.Served, error) {
+	imported, err := route.repository.Import.GetImport(ctx, uid, vr)
+	if err == nil {
+		return imported, finders.Served{Requested: vr.Language, Language: vr.Language, Reason: language.Requested}, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		route.logger.Info("Failed to get imported transcript", zap.Error(err), zap.Int("uid", uid))
+	}
+
+	return route.service.Negotiator.Find(ctx, vr, preferences, translate)
+}
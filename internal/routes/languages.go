@@ -0,0 +1,133 @@
+package routes
+
+import (
+	"fmt"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+	"transcribify/internal/routes/middlewares"
+	"transcribify/pkg/finders"
+	"transcribify/pkg/youtube"
+)
+
+// isMultiLanguage reports whether lang requests all available languages or a comma separated list
+func isMultiLanguage(lang string) bool {
+	return strings.Contains(lang, ",") || strings.EqualFold(strings.TrimSpace(lang), finders.AllLanguages)
+}
+
+// getVideoLanguages finds video in several languages in parallel. Every found language is stored
+// and added to user history, failed languages are reported in errors.
+// For `all` languages available ones are discovered with Accept-Language languages and English.
+func (route *Route) getVideoLanguages(w http.ResponseWriter, r *http.Request, uid int, vr models.VideoRequest) {
+	var (
+		ctx  = r.Context()
+		conf = config.Batch()
+	)
+
+	if !youtube.IsVideoID(vr.VideoID) {
+		renderError(w, r, http.StatusBadRequest, "invalid video id")
+
+		return
+	}
+
+	if format, err := negotiateFormat(r); err != nil || format != JSON {
+		renderError(w, r, http.StatusNotAcceptable, "several languages are returned only as json")
+
+		return
+	}
+
+	languages, all := finders.ParseLanguages(vr.Language)
+
+	result := models.LanguagesResult{
+		VideoID:        vr.VideoID,
+		Transcriptions: make(map[string][]models.Transcription),
+		Errors:         make(map[string]string),
+	}
+
+	found := make(map[string]*models.YTVideo)
+
+	if all {
+		seeds, _ := finders.ParseLanguages(strings.Join(append(acceptLanguages(r), "en"), ","))
+
+		video, lang, err := finders.Discover(ctx, route.service.Finder, vr.VideoID, seeds)
+		if err != nil {
+			renderError(w, r, http.StatusBadGateway, "failed to discover available languages")
+			route.logger.Info("Failed to discover languages", zap.String("video", vr.VideoID), zap.Error(err))
+
+			return
+		}
+
+		found[lang] = video
+		route.notifyVideo(ctx, uid, models.VideoRequest{VideoID: vr.VideoID, Language: lang}, video, nil)
+		languages, _ = finders.ParseLanguages(strings.Join(append(append([]string{lang}, languages...), video.AvailableLangs...), ","))
+	}
+
+	if len(languages) > conf.MaxVideos {
+		renderError(w, r, http.StatusBadRequest,
+			fmt.Sprintf("expected up to %d languages, got %d", conf.MaxVideos, len(languages)))
+
+		return
+	}
+
+	missing := make([]string, 0, len(languages))
+	for _, lang := range languages {
+		if _, ok := found[lang]; ok {
+			continue
+		}
+
+		if valid, err := middlewares.ValidateVideoRequest(models.VideoRequest{VideoID: vr.VideoID, Language: lang}); !valid || err != nil {
+			result.Errors[lang] = fmt.Sprintf("invalid language: %v", err)
+			continue
+		}
+		missing = append(missing, lang)
+	}
+
+	for _, res := range finders.FindLanguages(ctx, route.service.Finder, vr.VideoID, missing, conf.Concurrency) {
+		route.notifyVideo(ctx, uid, res.Request, res.Video, res.Err)
+
+		if res.Err != nil {
+			result.Errors[res.Request.Language] = res.Err.Error()
+			continue
+		}
+		found[res.Request.Language] = res.Video
+	}
+
+	for lang, video := range found {
+		if err := route.repository.User.PutUserVideo(ctx, uid, video.Id); err != nil {
+			route.logger.Info("Failed to put user video",
+				zap.Error(err), zap.Int("uid", uid), zap.Int("video.Id", video.Id))
+			result.Errors[lang] = "failed to save video in user history"
+
+			continue
+		}
+
+		result.Transcriptions[lang] = video.Transcription
+		if result.Title == "" {
+			result.Title = video.Title
+		}
+		if len(video.AvailableLangs) > len(result.AvailableLangs) {
+			result.AvailableLangs = video.AvailableLangs
+		}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, result)
+}
+
+// acceptLanguages returns primary languages of Accept-Language header in the order of appearance
+func acceptLanguages(r *http.Request) []string {
+	var languages []string
+
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		languages = append(languages, strings.ToLower(strings.SplitN(tag, "-", 2)[0]))
+	}
+
+	return languages
+}
@@ -56,6 +56,26 @@ func ValidateVideoRequest(request models.VideoRequest) (bool, error) {
 	}
 }
 
+func ValidateSearchRequest(request models.SearchRequest) (bool, error) {
+	v := validator.New()
+	switch err := v.Struct(request); err {
+	case nil:
+		return true, nil
+	default:
+		return false, err
+	}
+}
+
+func ValidateAskRequest(request models.AskRequest) (bool, error) {
+	v := validator.New()
+	switch err := v.Struct(request); err {
+	case nil:
+		return true, nil
+	default:
+		return false, err
+	}
+}
+
 func CheckCookie(r *http.Request, logger *zap.Logger) error {
 	var err error
 	if logger == nil {
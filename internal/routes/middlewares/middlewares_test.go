@@ -151,3 +151,45 @@ func Test_isValidVideoRequest(t *testing.T) {
 
 	}
 }
+
+func TestValidateSearchRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        models.SearchRequest
+		expectedRes bool
+	}{
+		{
+			name:        "Valid input",
+			data:        models.SearchRequest{Query: "golang", Limit: 10},
+			expectedRes: true,
+		},
+		{
+			name:        "Valid input with language",
+			data:        models.SearchRequest{Query: "golang", Language: "en", Limit: 10, Offset: 10},
+			expectedRes: true,
+		},
+		{
+			name:        "Empty query",
+			data:        models.SearchRequest{Limit: 10},
+			expectedRes: false,
+		},
+		{
+			name:        "Invalid language",
+			data:        models.SearchRequest{Query: "golang", Language: "r1", Limit: 10},
+			expectedRes: false,
+		},
+		{
+			name:        "Limit too big",
+			data:        models.SearchRequest{Query: "golang", Limit: 1000},
+			expectedRes: false,
+		},
+	}
+
+	for _, testcase := range tests {
+		t.Run(testcase.name, func(t *testing.T) {
+			valid, _ := ValidateSearchRequest(testcase.data)
+
+			assert.Equal(t, testcase.expectedRes, valid)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"transcribify/internal/models"
+	"transcribify/pkg/captions"
+	"transcribify/pkg/finders"
+)
+
+// JSON is the default response format of the video endpoint
+const JSON = "json"
+
+// acceptFormats maps `Accept` header media types to the response formats
+var acceptFormats = map[string]string{
+	"application/json":     JSON,
+	"application/x-subrip": string(captions.SRT),
+	"application/srt":      string(captions.SRT),
+	"text/srt":             string(captions.SRT),
+	"text/vtt":             string(captions.VTT),
+	"text/plain":           string(captions.Text),
+	"text/markdown":        string(captions.Markdown),
+}
+
+// negotiateFormat uses `format` query parameter if provided
+// otherwise `Accept` header. Returns JSON by default.
+func negotiateFormat(r *http.Request) (string, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		if strings.EqualFold(format, JSON) {
+			return JSON, nil
+		}
+
+		f, err := captions.ParseFormat(format)
+		if err != nil {
+			return "", err
+		}
+
+		return string(f), nil
+	}
+
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+
+		if format, ok := acceptFormats[mediaType]; ok {
+			return format, nil
+		}
+	}
+
+	return JSON, nil
+}
+
+// renderVideo writes video in negotiated format
+func renderVideo(w http.ResponseWriter, r *http.Request, format string, vr models.VideoRequest, video *models.YTVideo) error {
+	if format == JSON {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, video)
+
+		return nil
+	}
+
+	f, err := captions.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", f.ContentType())
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="%s.%s%s"`, vr.VideoID, vr.Language, f.Extension()))
+	w.WriteHeader(http.StatusOK)
+
+	timestamps, _ := strconv.ParseBool(r.URL.Query().Get("timestamps"))
+
+	return captions.Render(w, f, video, captions.Options{Timestamps: timestamps})
+}
+
+// setServedHeaders describes which language was served and why
+func setServedHeaders(w http.ResponseWriter, served finders.Served) {
+	w.Header().Set("Content-Language", served.Language)
+	w.Header().Set("X-Requested-Language", served.Requested)
+	w.Header().Set("X-Language-Reason", string(served.Reason))
+	if served.Source != "" {
+		w.Header().Set("X-Translated-From", served.Source)
+	}
+}
+
+// renderFindError maps failed video lookup to HTTP status and machine-readable code.
+// Upstream response body is logged, but isn`t sent to the client.
+func (route *Route) renderFindError(w http.ResponseWriter, r *http.Request, err error) {
+	code, status := finders.Classify(err)
+
+	if upstream := route.logFindError(err); upstream != nil && upstream.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(upstream.RetryAfter.Seconds()))))
+	}
+
+	message := err.Error()
+	if code == finders.CodeInternal {
+		message = "failed to find video"
+	}
+
+	render.Status(r, status)
+	render.JSON(w, r, map[string]string{"error": message, "code": string(code)})
+}
+
+// logFindError logs failed video lookup with upstream response if any and returns that response
+func (route *Route) logFindError(err error) *finders.UpstreamError {
+	code, _ := finders.Classify(err)
+	fields := []zap.Field{zap.Error(err), zap.String("code", string(code))}
+
+	var upstream *finders.UpstreamError
+	if errors.As(err, &upstream) {
+		fields = append(fields, zap.Int("upstream status", upstream.Status), zap.String("upstream body", upstream.Body))
+	}
+	route.logger.Info("Failed to find video", fields...)
+
+	return upstream
+}
+
+// renderError writes json object with error message
+func renderError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	render.Status(r, status)
+	render.JSON(w, r, map[string]string{"error": message})
+}
@@ -3,17 +3,31 @@ package routes
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
+	"transcribify/internal/config"
 	"transcribify/internal/models"
 	"transcribify/internal/routes/middlewares"
+	"transcribify/pkg/chapters"
+	"transcribify/pkg/finders"
+	"transcribify/pkg/jobs"
+	"transcribify/pkg/language"
+	"transcribify/pkg/playlist"
+	"transcribify/pkg/qa"
 	"transcribify/pkg/repository"
+	"transcribify/pkg/search"
+	"transcribify/pkg/semantic"
 	"transcribify/pkg/service"
+	"transcribify/pkg/summarizer"
+	"transcribify/pkg/youtube"
 )
 
 type Route struct {
@@ -38,16 +52,12 @@ func NewRoute(
 
 }
 
-// GetVideoTranscription Handle GET request for video with specified language
+// GetVideoTranscription Handle GET request for video with specified language.
+// Video can be specified by id or by any form of YouTube URL.
 func (route *Route) GetVideoTranscription(w http.ResponseWriter, r *http.Request) {
 
 	var (
-		vr = models.VideoRequest{
-			VideoID:  chi.URLParam(r, "id"),
-			Language: r.URL.Query().Get("lang"),
-		}
 		video = new(models.YTVideo)
-		err   error
 		ctx   = r.Context()
 	)
 	// Get the language from the query
@@ -60,6 +70,20 @@ func (route *Route) GetVideoTranscription(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	vr, start, err := parseVideoRequest(r)
+	if err != nil {
+		renderError(w, r, http.StatusBadRequest, err.Error())
+		route.logger.Info("Invalid video URL", zap.Error(err))
+
+		return
+	}
+
+	if isMultiLanguage(vr.Language) {
+		route.getVideoLanguages(w, r, uid, vr)
+
+		return
+	}
+
 	//Validating request
 	if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
 		w.WriteHeader(http.StatusConflict)
@@ -69,10 +93,22 @@ func (route *Route) GetVideoTranscription(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	video, err = route.service.Finder.Find(ctx, vr)
+	format, err := negotiateFormat(r)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		route.logger.Info("Failed to find video", zap.Error(err))
+		w.WriteHeader(http.StatusNotAcceptable)
+		route.logger.Info("Unsupported response format", zap.Error(err))
+
+		return
+	}
+
+	translate, _ := strconv.ParseBool(r.URL.Query().Get("translate"))
+	preferences := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+
+	video, served, err := route.findVideo(ctx, uid, vr, preferences, translate)
+	vr.Language = served.Language
+	route.notifyVideo(ctx, uid, vr, video, err)
+	if err != nil {
+		route.renderFindError(w, r, err)
 
 		return
 	}
@@ -86,8 +122,15 @@ func (route *Route) GetVideoTranscription(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	render.JSON(w, r, video)
+	if start > 0 {
+		w.Header().Set("X-Video-Start", strconv.Itoa(int(start.Seconds())))
+	}
+	setServedHeaders(w, served)
+
+	err = renderVideo(w, r, format, vr, video)
+	if err != nil {
+		route.logger.Info("Failed to render video", zap.Error(err), zap.String("format", format))
+	}
 }
 
 func (route *Route) HelloWorld(w http.ResponseWriter, r *http.Request) {
@@ -114,6 +157,38 @@ func (route *Route) SignUp(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// parseVideoRequest uses {id} url parameter if provided,
+// otherwise `url` query parameter or `url` field of json body for POST request.
+// Returns start time if video URL contains it.
+func parseVideoRequest(r *http.Request) (models.VideoRequest, time.Duration, error) {
+	vr := models.VideoRequest{
+		VideoID:  chi.URLParam(r, "id"),
+		Language: r.URL.Query().Get("lang"),
+	}
+	if vr.VideoID != "" {
+		return vr, 0, nil
+	}
+
+	input := models.VideoURLRequest{URL: r.URL.Query().Get("url")}
+	if input.URL == "" && r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			return vr, 0, err
+		}
+	}
+
+	if input.Language != "" {
+		vr.Language = input.Language
+	}
+
+	link, err := youtube.Parse(input.URL)
+	if err != nil {
+		return vr, 0, err
+	}
+	vr.VideoID = link.VideoID
+
+	return vr, link.Start, nil
+}
+
 // GetSubFromCtx returns -1 if 'sub' doesn`t provided in context.Context
 func GetSubFromCtx(ctx context.Context) int {
 	switch val := ctx.Value("sub"); val {
@@ -175,7 +250,7 @@ func (route *Route) GetUserVideo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	videos, err := route.repository.User.GetUserVideos(ctx, uid, l, o)
+	videos, err := route.repository.User.GetUserVideos(ctx, uid, l, o, strings.TrimSpace(r.URL.Query().Get("keyword")))
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		route.logger.Info("Failed to get user videos", zap.Error(err))
@@ -187,24 +262,732 @@ func (route *Route) GetUserVideo(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, videos)
 }
 
-func (route *Route) getSignInData(r *http.Request) (*models.User, error) {
-	user := new(models.User)
-	if err := json.NewDecoder(r.Body).Decode(user); err != nil {
-		return nil, err
+// SearchVideos Handle GET request for full-text search across stored transcriptions
+func (route *Route) SearchVideos(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if uid := GetSubFromCtx(ctx); uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
 	}
 
-	return user, nil
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil {
+		limit = 10
+	}
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	sr := models.SearchRequest{
+		Query:    strings.TrimSpace(r.URL.Query().Get("q")),
+		Language: r.URL.Query().Get("lang"),
+		Limit:    limit,
+		Offset:   (page - 1) * limit,
+	}
+
+	if valid, err := middlewares.ValidateSearchRequest(sr); !valid || err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		route.logger.Info("Invalid search request",
+			zap.Any("search request", sr), zap.Error(err), zap.Bool("valid", valid))
+
+		return
+	}
+
+	results, err := route.repository.Search.Search(ctx, sr)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to search videos", zap.Error(err))
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, results)
 }
 
-func formatPrompt(video *models.YTVideo) (string, error) {
+// SearchVideoTranscription Handle GET request for search inside single video transcription
+func (route *Route) SearchVideoTranscription(w http.ResponseWriter, r *http.Request) {
 	var (
-		promt    = "I want you to summarize. I give you a youtube video transcription. You giving me summarizing info, what is going on in the video. Here is transcriptions: %s"
-		toInsert strings.Builder
+		vr = models.VideoRequest{
+			VideoID:  chi.URLParam(r, "id"),
+			Language: r.URL.Query().Get("lang"),
+		}
+		query = r.URL.Query().Get("q")
+		mode  = search.Mode(r.URL.Query().Get("mode"))
+		ctx   = r.Context()
 	)
-	err := json.NewEncoder(&toInsert).Encode(video)
+
+	if uid := GetSubFromCtx(ctx); uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+		w.WriteHeader(http.StatusConflict)
+		route.logger.Info("Invalid video request",
+			zap.Any("video request", vr), zap.Error(err), zap.Bool("valid", valid))
+
+		return
+	}
+
+	around, err := strconv.Atoi(r.URL.Query().Get("context"))
+	if err != nil || around < 0 || around > 10 {
+		around = 1
+	}
+
+	matcher, err := search.NewMatcher(mode, query)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		route.logger.Info("Invalid search query",
+			zap.String("q", query), zap.String("mode", string(mode)), zap.Error(err))
+
+		return
+	}
+
+	video, err := route.service.Finder.Find(ctx, vr)
 	if err != nil {
-		return "", err
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to find video", zap.Error(err))
+
+		return
+	}
+
+	if mode == "" {
+		mode = search.Phrase
+	}
+	result := models.TranscriptSearchResult{
+		VideoID:  vr.VideoID,
+		Language: vr.Language,
+		Query:    query,
+		Mode:     string(mode),
+		Hits:     make([]models.TranscriptHit, 0),
+	}
+
+	for _, hit := range search.Find(video.Transcription, matcher, around) {
+		result.Hits = append(result.Hits, models.TranscriptHit{
+			Index:   hit.Index,
+			Start:   hit.Segment.Start,
+			Segment: hit.Segment,
+			Before:  hit.Before,
+			After:   hit.After,
+			Link:    DeepLink(vr.VideoID, hit.Segment.Start),
+		})
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, result)
+}
+
+// GetVideoBatch Handle POST request for several videos and playlist videos with the same language.
+// Every found video is attached to user history. Errors are reported per video.
+func (route *Route) GetVideoBatch(w http.ResponseWriter, r *http.Request) {
+	var (
+		input = new(models.BatchRequest)
+		conf  = config.Batch()
+		ctx   = r.Context()
+	)
+
+	uid := GetSubFromCtx(ctx)
+	if uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
 	}
 
-	return fmt.Sprintf(promt, toInsert.String()), nil
+	if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		route.logger.Info("Invalid batch request", zap.Error(err))
+
+		return
+	}
+
+	inputs := input.Videos
+	var playlistPage *playlist.Page
+	if input.Playlist != "" {
+		id, err := youtube.ParsePlaylist(input.Playlist)
+		if err != nil {
+			renderError(w, r, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		if input.PlaylistOffset < 0 {
+			renderError(w, r, http.StatusBadRequest,
+				fmt.Sprintf("expected non-negative playlist offset, got %d", input.PlaylistOffset))
+
+			return
+		}
+
+		// playlist longer than the batch is returned in pages
+		free := conf.MaxVideos - len(inputs)
+		if free < 0 {
+			free = 0
+		}
+
+		playlistPage, err = route.service.Playlists.Resolve(ctx, id, input.PlaylistOffset, free)
+		if err != nil {
+			renderError(w, r, http.StatusBadGateway, err.Error())
+			route.logger.Info("Failed to resolve playlist", zap.String("playlist", id), zap.Error(err))
+
+			return
+		}
+
+		if input.PlaylistOffset > playlistPage.Total {
+			renderError(w, r, http.StatusBadRequest,
+				fmt.Sprintf("expected playlist offset from 0 to %d, got %d", playlistPage.Total, input.PlaylistOffset))
+
+			return
+		}
+
+		inputs = append(inputs, playlistPage.IDs...)
+	}
+
+	if len(inputs) == 0 || len(inputs) > conf.MaxVideos {
+		renderError(w, r, http.StatusBadRequest,
+			fmt.Sprintf("expected from 1 to %d videos, got %d", conf.MaxVideos, len(inputs)))
+
+		return
+	}
+
+	if playlistPage != nil {
+		if next := input.PlaylistOffset + len(playlistPage.IDs); next < playlistPage.Total {
+			w.Header().Set("X-Playlist-Next-Offset", strconv.Itoa(next))
+		}
+		w.Header().Set("X-Playlist-Total", strconv.Itoa(playlistPage.Total))
+	}
+
+	var (
+		items    = make([]models.BatchItem, len(inputs))
+		requests = make([]models.VideoRequest, 0, len(inputs))
+		indexes  = make([]int, 0, len(inputs))
+	)
+
+	for i, raw := range inputs {
+		items[i].Input = raw
+
+		link, err := youtube.Parse(raw)
+		if err != nil {
+			items[i].Error = err.Error()
+			continue
+		}
+
+		vr := models.VideoRequest{VideoID: link.VideoID, Language: input.Language}
+		if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+			items[i].Error = fmt.Sprintf("invalid video request: %v", err)
+			continue
+		}
+
+		items[i].VideoID = vr.VideoID
+		requests = append(requests, vr)
+		indexes = append(indexes, i)
+	}
+
+	for j, result := range finders.FindAll(ctx, route.service.Finder, requests, conf.Concurrency) {
+		item := &items[indexes[j]]
+		route.notifyVideo(ctx, uid, result.Request, result.Video, result.Err)
+
+		if result.Err != nil {
+			item.Error = result.Err.Error()
+			continue
+		}
+
+		if err := route.repository.User.PutUserVideo(ctx, uid, result.Video.Id); err != nil {
+			route.logger.Info("Failed to put user video",
+				zap.Error(err), zap.Int("uid", uid), zap.Int("video.Id", result.Video.Id))
+			item.Error = "failed to save video in user history"
+			continue
+		}
+
+		item.Video = result.Video
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, items)
+}
+
+// CreateJob Handle POST request to fetch video asynchronously.
+// Video is specified by `url` and `lang` fields of json body or query parameters.
+func (route *Route) CreateJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uid := GetSubFromCtx(ctx)
+	if uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	vr, _, err := parseVideoRequest(r)
+	if err != nil {
+		renderError(w, r, http.StatusBadRequest, err.Error())
+		route.logger.Info("Invalid video URL", zap.Error(err))
+
+		return
+	}
+
+	if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+		w.WriteHeader(http.StatusConflict)
+		route.logger.Info("Invalid video request",
+			zap.Any("video request", vr), zap.Error(err), zap.Bool("valid", valid))
+
+		return
+	}
+
+	job, err := route.service.Jobs.Enqueue(ctx, uid, vr)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to enqueue job", zap.Error(err))
+
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/jobs/%d", job.ID))
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, job)
+}
+
+// GetJob Handle GET request for state of the user job
+func (route *Route) GetJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uid := GetSubFromCtx(ctx)
+	if uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		route.logger.Info("Invalid job id", zap.Error(err))
+
+		return
+	}
+
+	job, err := route.repository.Job.GetJob(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && job.UserID != uid) {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to get job", zap.Error(err))
+
+		return
+	}
+
+	if job.State == models.JobSucceeded {
+		job.Result = jobs.ResultLink(job.VideoID, job.Language)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, job)
+}
+
+// GetVideoChapters Handle GET request for video chapters. Chapters are parsed from description timestamps
+// or generated from the transcript, and stored with the video on the first request.
+func (route *Route) GetVideoChapters(w http.ResponseWriter, r *http.Request) {
+	var (
+		vr = models.VideoRequest{
+			VideoID:  chi.URLParam(r, "id"),
+			Language: r.URL.Query().Get("lang"),
+		}
+		ctx = r.Context()
+	)
+
+	if uid := GetSubFromCtx(ctx); uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+		w.WriteHeader(http.StatusConflict)
+		route.logger.Info("Invalid video request",
+			zap.Any("video request", vr), zap.Error(err), zap.Bool("valid", valid))
+
+		return
+	}
+
+	video, err := route.service.Finder.Find(ctx, vr)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to find video", zap.Error(err))
+
+		return
+	}
+
+	if video.Chapters == nil {
+		video.Chapters, video.ChaptersSource = chapters.Extract(video, vr.Language)
+
+		if err = route.repository.Video.SetChapters(ctx, video.Id, video.ChaptersSource, video.Chapters); err != nil {
+			route.logger.Info("Failed to store chapters", zap.Int("video", video.Id), zap.Error(err))
+		}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, models.ChaptersResult{
+		VideoID:  vr.VideoID,
+		Language: vr.Language,
+		Source:   video.ChaptersSource,
+		Chapters: video.Chapters,
+	})
+}
+
+// SemanticSearch Handle GET request for videos with transcript windows similar to the query
+func (route *Route) SemanticSearch(w http.ResponseWriter, r *http.Request) {
+	var (
+		query    = r.URL.Query().Get("q")
+		language = r.URL.Query().Get("lang")
+		ctx      = r.Context()
+	)
+
+	if uid := GetSubFromCtx(ctx); uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	results, err := route.service.Semantic.Search(ctx, query, language, limit)
+	if errors.Is(err, semantic.ErrEmptyQuery) {
+		renderError(w, r, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to run semantic search", zap.String("q", query), zap.Error(err))
+
+		return
+	}
+
+	for i := range results {
+		for j := range results[i].Passages {
+			results[i].Passages[j].Link = DeepLink(results[i].VideoID, results[i].Passages[j].Start)
+		}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, results)
+}
+
+// GetSimilarVideos Handle GET request for videos related to the video across stored transcriptions
+func (route *Route) GetSimilarVideos(w http.ResponseWriter, r *http.Request) {
+	var (
+		vr = models.VideoRequest{
+			VideoID:  chi.URLParam(r, "id"),
+			Language: r.URL.Query().Get("lang"),
+		}
+		ctx = r.Context()
+	)
+
+	if uid := GetSubFromCtx(ctx); uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+		w.WriteHeader(http.StatusConflict)
+		route.logger.Info("Invalid video request",
+			zap.Any("video request", vr), zap.Error(err), zap.Bool("valid", valid))
+
+		return
+	}
+
+	video, err := route.service.Finder.Find(ctx, vr)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to find video", zap.Error(err))
+
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	results, err := route.service.Semantic.Similar(ctx, vr, video, limit)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to find similar videos", zap.Int("video", video.Id), zap.Error(err))
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, results)
+}
+
+// GetVideoKeywords Handle GET request for video keyphrases. Keywords are extracted on the first request
+// and can be used to filter user history.
+func (route *Route) GetVideoKeywords(w http.ResponseWriter, r *http.Request) {
+	var (
+		vr = models.VideoRequest{
+			VideoID:  chi.URLParam(r, "id"),
+			Language: r.URL.Query().Get("lang"),
+		}
+		ctx = r.Context()
+	)
+
+	if uid := GetSubFromCtx(ctx); uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+		w.WriteHeader(http.StatusConflict)
+		route.logger.Info("Invalid video request",
+			zap.Any("video request", vr), zap.Error(err), zap.Bool("valid", valid))
+
+		return
+	}
+
+	video, err := route.service.Finder.Find(ctx, vr)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to find video", zap.Error(err))
+
+		return
+	}
+
+	keywords, err := route.service.Keywords.Keywords(ctx, video, vr.Language)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to extract keywords", zap.Int("video", video.Id), zap.Error(err))
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, models.KeywordsResult{
+		VideoID:  vr.VideoID,
+		Language: vr.Language,
+		Keywords: keywords,
+	})
+}
+
+// AskVideo Handle POST request with question about video. Answer cites transcript passages retrieved with BM25.
+// Retrieval mode returns passages only and works without model.
+func (route *Route) AskVideo(w http.ResponseWriter, r *http.Request) {
+	var (
+		input = new(models.AskRequest)
+		ctx   = r.Context()
+	)
+
+	if uid := GetSubFromCtx(ctx); uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+		renderError(w, r, http.StatusBadRequest, "invalid json body")
+		route.logger.Info("Failed to decode question", zap.Error(err))
+
+		return
+	}
+
+	input.Question = strings.TrimSpace(input.Question)
+	if valid, err := middlewares.ValidateAskRequest(*input); !valid || err != nil {
+		renderError(w, r, http.StatusBadRequest, "question is required and limited to 500 characters")
+		route.logger.Info("Invalid question", zap.Error(err))
+
+		return
+	}
+
+	vr := models.VideoRequest{VideoID: chi.URLParam(r, "id"), Language: input.Language}
+	if vr.Language == "" {
+		vr.Language = r.URL.Query().Get("lang")
+	}
+
+	if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+		w.WriteHeader(http.StatusConflict)
+		route.logger.Info("Invalid video request",
+			zap.Any("video request", vr), zap.Error(err), zap.Bool("valid", valid))
+
+		return
+	}
+
+	mode, err := route.service.Answerer.ParseMode(input.Mode)
+	if errors.Is(err, qa.ErrNotConfigured) {
+		renderError(w, r, http.StatusServiceUnavailable, err.Error())
+
+		return
+	}
+	if err != nil {
+		renderError(w, r, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	video, err := route.service.Finder.Find(ctx, vr)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to find video", zap.Error(err))
+
+		return
+	}
+
+	result, err := route.service.Answerer.Ask(ctx, video, input.Question, mode, input.Limit)
+	if errors.Is(err, qa.ErrEmptyQuestion) {
+		renderError(w, r, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	if err != nil {
+		renderError(w, r, http.StatusBadGateway, "failed to answer question")
+		route.logger.Info("Failed to answer question", zap.Error(err))
+
+		return
+	}
+
+	answer := models.Answer{
+		VideoID:   vr.VideoID,
+		Language:  vr.Language,
+		Question:  input.Question,
+		Mode:      string(mode),
+		Answer:    result.Answer,
+		Model:     result.Model,
+		Citations: make([]models.Citation, 0, len(result.Cited)),
+		Passages:  make([]models.Citation, 0, len(result.Passages)),
+	}
+
+	for _, p := range result.Passages {
+		answer.Passages = append(answer.Passages, models.Citation{
+			Start:        p.Start,
+			End:          p.End,
+			FirstSegment: p.FirstSegment,
+			LastSegment:  p.LastSegment,
+			Text:         p.Text,
+			Score:        p.Score,
+			Link:         DeepLink(vr.VideoID, p.Start),
+		})
+	}
+	for _, i := range result.Cited {
+		answer.Citations = append(answer.Citations, answer.Passages[i])
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, answer)
+}
+
+// GetVideoSummary Handle GET request for video summary. Summaries are cached by video, language, style and model.
+func (route *Route) GetVideoSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if uid := GetSubFromCtx(ctx); uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	if route.service.Summarizer == nil {
+		renderError(w, r, http.StatusServiceUnavailable, summarizer.ErrNotConfigured.Error())
+
+		return
+	}
+
+	vr, _, err := parseVideoRequest(r)
+	if err != nil {
+		renderError(w, r, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+		w.WriteHeader(http.StatusConflict)
+		route.logger.Info("Invalid video request",
+			zap.Any("video request", vr), zap.Error(err), zap.Bool("valid", valid))
+
+		return
+	}
+
+	style, err := summarizer.ParseStyle(r.URL.Query().Get("style"))
+	if err != nil {
+		renderError(w, r, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	model := route.service.Summarizer.Model()
+
+	summary, err := route.repository.Summary.GetSummary(ctx, vr, string(style), model)
+	if err == nil {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, summary)
+
+		return
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		route.logger.Info("Failed to get cached summary", zap.Error(err))
+	}
+
+	video, err := route.service.Finder.Find(ctx, vr)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to find video", zap.Error(err))
+
+		return
+	}
+
+	text, err := route.service.Summarizer.Summarize(ctx, video, style)
+	if errors.Is(err, summarizer.ErrEmptyTranscript) {
+		renderError(w, r, http.StatusUnprocessableEntity, err.Error())
+
+		return
+	}
+	if err != nil {
+		renderError(w, r, http.StatusBadGateway, "failed to summarize video")
+		route.logger.Info("Failed to summarize video", zap.Error(err))
+
+		return
+	}
+
+	summary = &models.Summary{
+		VideoID:  vr.VideoID,
+		Language: vr.Language,
+		Style:    string(style),
+		Model:    model,
+		Summary:  text,
+	}
+
+	if err = route.repository.Summary.PutSummary(ctx, summary); err != nil {
+		route.logger.Info("Failed to cache summary", zap.Error(err))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, summary)
+}
+
+// DeepLink returns YouTube link which starts video at specified second
+func DeepLink(videoID string, start float64) string {
+	return fmt.Sprintf("https://youtu.be/%s?t=%d", videoID, int(start))
+}
+
+func (route *Route) getSignInData(r *http.Request) (*models.User, error) {
+	user := new(models.User)
+	if err := json.NewDecoder(r.Body).Decode(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
 }
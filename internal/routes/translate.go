@@ -0,0 +1,99 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	"net/http"
+	"transcribify/internal/models"
+	"transcribify/internal/routes/middlewares"
+	"transcribify/pkg/finders"
+	"transcribify/pkg/language"
+	"transcribify/pkg/translate"
+)
+
+// GetVideoTranslation Handle GET request for machine translation of the video transcription.
+// Translation is cached apart from original captions and rendered in negotiated format.
+func (route *Route) GetVideoTranslation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uid := GetSubFromCtx(ctx)
+	if uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	if route.service.Translator == nil {
+		renderError(w, r, http.StatusServiceUnavailable, translate.ErrNotConfigured.Error())
+
+		return
+	}
+
+	var (
+		id     = chi.URLParam(r, "id")
+		source = models.VideoRequest{VideoID: id, Language: r.URL.Query().Get("from")}
+		target = models.VideoRequest{VideoID: id, Language: r.URL.Query().Get("to")}
+	)
+
+	for _, vr := range []models.VideoRequest{source, target} {
+		if valid, err := middlewares.ValidateVideoRequest(vr); !valid || err != nil {
+			w.WriteHeader(http.StatusConflict)
+			route.logger.Info("Invalid video request",
+				zap.Any("video request", vr), zap.Error(err), zap.Bool("valid", valid))
+
+			return
+		}
+	}
+
+	if language.Equal(source.Language, target.Language) {
+		renderError(w, r, http.StatusBadRequest, translate.ErrSameLanguage.Error())
+
+		return
+	}
+
+	format, err := negotiateFormat(r)
+	if err != nil {
+		w.WriteHeader(http.StatusNotAcceptable)
+		route.logger.Info("Unsupported response format", zap.Error(err))
+
+		return
+	}
+
+	video, err := route.service.Finder.Find(ctx, source)
+	if err != nil {
+		route.renderFindError(w, r, err)
+
+		return
+	}
+
+	translated, err := route.service.Translator.TranslateVideo(ctx, video, source.Language, target)
+	if err != nil {
+		renderError(w, r, http.StatusBadGateway, "failed to translate video")
+		route.logger.Info("Failed to translate video", zap.Error(err),
+			zap.String("from", source.Language), zap.String("to", target.Language))
+
+		return
+	}
+
+	err = route.repository.User.PutUserVideo(ctx, uid, translated.Id)
+	if err != nil {
+		route.logger.Info("Failed to put user video",
+			zap.Error(err), zap.Int("uid", uid), zap.Int("video.Id", translated.Id))
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	setServedHeaders(w, finders.Served{
+		Requested: target.Language,
+		Language:  target.Language,
+		Reason:    language.Translated,
+		Source:    source.Language,
+	})
+
+	err = renderVideo(w, r, format, target, translated)
+	if err != nil {
+		route.logger.Info("Failed to render video", zap.Error(err), zap.String("format", format))
+	}
+}
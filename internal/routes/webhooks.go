@@ -0,0 +1,262 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"transcribify/internal/models"
+	"transcribify/pkg/jobs"
+	"transcribify/pkg/webhook"
+)
+
+// notifyVideo sends video.completed or video.failed event to user webhooks.
+// Videos read from repository aren`t notified, video.completed is sent once they are fetched.
+func (route *Route) notifyVideo(ctx context.Context, uid int, vr models.VideoRequest, video *models.YTVideo, err error) {
+	if route.service.Webhooks == nil || (err == nil && !video.Fetched) {
+		return
+	}
+
+	event := models.VideoEvent{VideoID: vr.VideoID, Language: vr.Language}
+	if err != nil {
+		event.Error = err.Error()
+		route.service.Webhooks.Notify(ctx, uid, models.EventVideoFailed, event)
+
+		return
+	}
+
+	event.Title = video.Title
+	event.Result = jobs.ResultLink(vr.VideoID, vr.Language)
+	route.service.Webhooks.Notify(ctx, uid, models.EventVideoCompleted, event)
+}
+
+// CreateWebhook Handle POST request to register user webhook. Secret is generated if not provided.
+func (route *Route) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx   = r.Context()
+		input = new(models.Webhook)
+	)
+
+	uid := GetSubFromCtx(ctx)
+	if uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		route.logger.Info("Invalid webhook", zap.Error(err))
+
+		return
+	}
+
+	if err := validator.New().Struct(input); err != nil {
+		renderError(w, r, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if route.service.Webhooks != nil {
+		if err := route.service.Webhooks.CheckURL(ctx, input.URL); err != nil {
+			renderError(w, r, http.StatusBadRequest, err.Error())
+			route.logger.Info("Forbidden webhook URL", zap.Error(err), zap.String("url", input.URL))
+
+			return
+		}
+	}
+
+	if input.Secret == "" {
+		secret, err := webhook.NewSecret()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			route.logger.Info("Failed to generate webhook secret", zap.Error(err))
+
+			return
+		}
+		input.Secret = secret
+	}
+	input.UserID = uid
+
+	if err := route.repository.Webhook.CreateWebhook(ctx, input); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to create webhook", zap.Error(err))
+
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, input)
+}
+
+// GetWebhooks Handle GET request for user webhooks
+func (route *Route) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uid := GetSubFromCtx(ctx)
+	if uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	webhooks, err := route.repository.Webhook.GetWebhooks(ctx, uid)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to get webhooks", zap.Error(err))
+
+		return
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, webhooks)
+}
+
+// DeleteWebhook Handle DELETE request for user webhook
+func (route *Route) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uid := GetSubFromCtx(ctx)
+	if uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	err = route.repository.Webhook.DeleteWebhook(ctx, uid, id)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		w.WriteHeader(http.StatusNotFound)
+	case err != nil:
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to delete webhook", zap.Error(err))
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// GetWebhookDeliveries Handle GET request for delivery log of user webhook
+func (route *Route) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	hook, ok := route.userWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	deliveries, err := route.repository.Webhook.GetDeliveries(ctx, hook.ID, limit, (page-1)*limit)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to get deliveries", zap.Error(err))
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, deliveries)
+}
+
+// RedeliverWebhook Handle POST request to send delivery of user webhook again
+func (route *Route) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	hook, ok := route.userWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "delivery"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	delivery, err := route.repository.Webhook.GetDelivery(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && delivery.WebhookID != hook.ID) {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to get delivery", zap.Error(err))
+
+		return
+	}
+
+	redelivery, err := route.service.Webhooks.Redeliver(ctx, hook, delivery)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to redeliver", zap.Error(err))
+
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, redelivery)
+}
+
+// userWebhook writes error status if {id} isn`t webhook of the user
+func (route *Route) userWebhook(w http.ResponseWriter, r *http.Request) (*models.Webhook, bool) {
+	ctx := r.Context()
+
+	uid := GetSubFromCtx(ctx)
+	if uid == -1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		route.logger.Info("Invalid user id", zap.Int("uid", uid))
+
+		return nil, false
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return nil, false
+	}
+
+	hook, err := route.repository.Webhook.GetWebhook(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && hook.UserID != uid) {
+		w.WriteHeader(http.StatusNotFound)
+
+		return nil, false
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		route.logger.Info("Failed to get webhook", zap.Error(err))
+
+		return nil, false
+	}
+
+	return hook, true
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"expvar"
 	"github.com/go-chi/chi/v5"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
@@ -11,14 +12,25 @@ import (
 	"net/http"
 	"os"
 	"time"
+	"transcribify/internal/config"
 	"transcribify/internal/routes"
 	"transcribify/internal/routes/middlewares"
 	"transcribify/pkg/dbclient"
+	"transcribify/pkg/embedding"
 	"transcribify/pkg/finders"
 	"transcribify/pkg/hash"
+	"transcribify/pkg/jobs"
+	"transcribify/pkg/keywords"
+	"transcribify/pkg/llm"
 	"transcribify/pkg/logging"
+	"transcribify/pkg/playlist"
+	"transcribify/pkg/qa"
 	repo "transcribify/pkg/repository"
+	"transcribify/pkg/semantic"
 	"transcribify/pkg/service"
+	"transcribify/pkg/summarizer"
+	"transcribify/pkg/translate"
+	"transcribify/pkg/webhook"
 )
 
 func Server(ctx context.Context) *http.Server {
@@ -28,17 +40,159 @@ func Server(ctx context.Context) *http.Server {
 	}
 	repository := Repository(ctx)
 	client := Client()
+	logger := Logger()
+
+	services := service.New(*repository, finders.NewAPIFinder(Providers(client, logger), repository.Video), hash.NewBCHasher(bcrypt.DefaultCost))
+	services.Webhooks = Webhooks(ctx, logger, client, repository)
+	services.Jobs = Jobs(ctx, logger, repository, services.Finder, services.Webhooks)
+	services.Playlists = Playlists(client)
+	chat := Chat(client)
+	services.Summarizer = Summarizer(chat)
+	services.Answerer = qa.New(chat)
+	services.Keywords = Keywords(ctx, logger, repository)
+	services.Semantic = Semantic(ctx, logger, client, repository)
+	services.Translator = Translator(client, repository)
+	if services.Translator != nil {
+		services.Negotiator = finders.NewNegotiator(services.Finder, services.Translator)
+	}
+
 	return &http.Server{
 		Addr: ":" + os.Getenv("APP_PORT"),
 		Handler: Router(
-			Logger(),
+			logger,
 			client,
-			service.New(*repository, finders.NewAPIFinder(client, repository.Video), hash.NewBCHasher(bcrypt.DefaultCost)),
+			services,
 			repository,
 		),
 	}
 }
 
+// Playlists uses YouTube Data API if YOUTUBE_API_KEY provided
+func Playlists(client *http.Client) playlist.Resolver {
+	conf := config.Playlist()
+	if conf.APIKey == "" {
+		return playlist.NoopResolver{}
+	}
+
+	return playlist.NewDataAPIResolver(client, conf.BaseURL, conf.APIKey)
+}
+
+// Chat returns nil if neither OPENAI_API_KEY nor OPENAI_BASE_URL provided
+func Chat(client *http.Client) llm.Chat {
+	conf := config.LLM()
+	if conf.APIKey == "" && conf.BaseURL == "" {
+		return nil
+	}
+
+	return llm.NewOpenAI(client, conf.BaseURL, conf.APIKey, conf.Model)
+}
+
+// Summarizer returns nil if chat isn`t configured
+func Summarizer(chat llm.Chat) summarizer.Summarizer {
+	if chat == nil {
+		return nil
+	}
+
+	conf := config.LLM()
+	model := summarizer.ConfigFor(conf.Model)
+	if conf.ChunkTokens > 0 {
+		model.ChunkTokens = conf.ChunkTokens
+	}
+	if conf.ChunkOverlap > 0 {
+		model.OverlapTokens = conf.ChunkOverlap
+	}
+	if conf.Concurrency > 0 {
+		model.Concurrency = conf.Concurrency
+	}
+
+	return summarizer.NewWithConfig(chat, model)
+}
+
+// Translator returns nil if TRANSLATOR isn`t set
+func Translator(client *http.Client, repository *repo.Repository) *translate.Service {
+	conf := config.Translation()
+
+	var translator translate.Translator
+	switch conf.Translator {
+	case "":
+		return nil
+	case "libretranslate":
+		if conf.BaseURL == "" {
+			log.Fatal("TRANSLATE_BASE_URL is required for libretranslate translator")
+		}
+		translator = translate.NewLibreTranslate(client, conf.BaseURL, conf.APIKey)
+	case "stub":
+		translator = translate.Stub{}
+	default:
+		log.Fatalf("unknown translator %q", conf.Translator)
+	}
+
+	return translate.NewService(translator, repository.Translation, conf.Batch)
+}
+
+// Webhooks starts delivery workers. Dispatcher stops when ctx is done.
+func Webhooks(ctx context.Context, logger *zap.Logger, client *http.Client, repository *repo.Repository) *webhook.Dispatcher {
+	dispatcher := webhook.NewDispatcher(repository.Webhook, client, logger, config.Webhook())
+	dispatcher.Start(ctx)
+
+	return dispatcher
+}
+
+// Keywords starts background refreshing of keywords statistics
+func Keywords(ctx context.Context, logger *zap.Logger, repository *repo.Repository) *keywords.Tagger {
+	tagger := keywords.NewTagger(repository.Keyword, repository.Video, logger, config.Keywords())
+	tagger.Start(ctx)
+
+	return tagger
+}
+
+// Semantic loads embeddings index and starts embedding stored videos
+func Semantic(ctx context.Context, logger *zap.Logger, client *http.Client, repository *repo.Repository) *semantic.Index {
+	conf := config.Embedding()
+
+	var embedder embedding.Embedder
+	switch conf.Embedder {
+	case "http":
+		embedder = embedding.NewHTTP(client, conf.BaseURL, conf.APIKey, conf.Model, conf.Dimensions)
+	case "hashing":
+		embedder = embedding.NewHashing(conf.Dimensions, keywords.Stopwords("en"))
+	default:
+		log.Fatalf("unknown embedder %q", conf.Embedder)
+	}
+
+	index := semantic.NewIndex(embedder, repository.Embedding, repository.Video, logger, conf)
+	index.Start(ctx)
+
+	return index
+}
+
+// Jobs starts worker pool configured by JOB_WORKERS and JOB_POLL_INTERVAL. Pool stops when ctx is done.
+func Jobs(
+	ctx context.Context,
+	logger *zap.Logger,
+	repository *repo.Repository,
+	finder finders.Finder,
+	notifier webhook.Notifier,
+) *jobs.Pool {
+	pool := jobs.NewPool(repository.Job, repository.User, finder, notifier, logger, config.Jobs())
+
+	if err := pool.Start(ctx); err != nil {
+		log.Fatal(err)
+	}
+
+	return pool
+}
+
+// Providers returns transcript providers registry configured by TRANSCRIPT_PROVIDERS
+func Providers(client *http.Client, logger *zap.Logger) *finders.Registry {
+	registry, err := finders.NewRegistryFromConfig(client, config.Finder(), logger)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return registry
+}
+
 func Repository(ctx context.Context) *repo.Repository {
 
 	client, err := dbclient.NewClient(ctx)
@@ -61,13 +215,107 @@ func Router(logger *zap.Logger, client *http.Client, service *service.Services,
 
 	auth := middlewares.Identify(logger, service.Manager)
 
+	if config.Route().DebugVars {
+		//GET /debug/vars
+		router.Handle("/debug/vars", expvar.Handler())
+	}
+
 	router.Route("/api/v1", func(r chi.Router) {
 
-		//GET /api/v1/video/{id}?lang=
+		//GET /api/v1/video/{id}?lang=&format=&timestamps=
 		r.With(middlewares.LogVideoRequest(logger), auth).
 			Get("/video/{id}", route.GetVideoTranscription)
 
-		//GET /api/v1/user/history/{page}?limit=
+		//GET /api/v1/video?url=&lang=&format=&timestamps=
+		r.With(auth).
+			Get("/video", route.GetVideoTranscription)
+
+		//POST /api/v1/video
+		r.With(auth).
+			Post("/video", route.GetVideoTranscription)
+
+		//POST /api/v1/video/batch
+		r.With(auth).
+			Post("/video/batch", route.GetVideoBatch)
+
+		//GET /api/v1/video/{id}/translate?from=&to=&format=
+		r.With(auth).
+			Get("/video/{id}/translate", route.GetVideoTranslation)
+
+		//GET /api/v1/video/{id}/bilingual?primary=&secondary=&format=&timestamps=
+		r.With(auth).
+			Get("/video/{id}/bilingual", route.GetVideoBilingual)
+
+		//GET /api/v1/video/{id}/events?lang=
+		r.With(auth).
+			Get("/video/{id}/events", route.GetVideoEvents)
+
+		//GET /api/v1/video/{id}/chapters?lang=
+		r.With(auth).
+			Get("/video/{id}/chapters", route.GetVideoChapters)
+
+		//POST /api/v1/video/{id}/ask
+		r.With(auth).
+			Post("/video/{id}/ask", route.AskVideo)
+
+		//GET /api/v1/video/{id}/similar?lang=&limit=
+		r.With(auth).
+			Get("/video/{id}/similar", route.GetSimilarVideos)
+
+		//GET /api/v1/video/{id}/keywords?lang=
+		r.With(auth).
+			Get("/video/{id}/keywords", route.GetVideoKeywords)
+
+		//GET /api/v1/video/{id}/summary?lang=&style=
+		r.With(auth).
+			Get("/video/{id}/summary", route.GetVideoSummary)
+
+		//GET /api/v1/video/{id}/search?lang=&q=&mode=&context=
+		r.With(auth).
+			Get("/video/{id}/search", route.SearchVideoTranscription)
+
+		//POST /api/v1/transcripts/import?lang=&title=&format=&id=
+		r.With(auth).
+			Post("/transcripts/import", route.ImportTranscript)
+
+		//GET /api/v1/search?q=&lang=&page=&limit=
+		r.With(auth).
+			Get("/search", route.SearchVideos)
+
+		//GET /api/v1/search/semantic?q=&lang=&limit=
+		r.With(auth).
+			Get("/search/semantic", route.SemanticSearch)
+
+		r.Route("/jobs", func(r chi.Router) {
+			r.Use(auth)
+
+			//POST /api/v1/jobs
+			r.Post("/", route.CreateJob)
+
+			//GET /api/v1/jobs/{id}
+			r.Get("/{id}", route.GetJob)
+		})
+
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Use(auth)
+
+			//POST /api/v1/webhooks
+			r.Post("/", route.CreateWebhook)
+
+			//GET /api/v1/webhooks
+			r.Get("/", route.GetWebhooks)
+
+			//DELETE /api/v1/webhooks/{id}
+			r.Delete("/{id}", route.DeleteWebhook)
+
+			//GET /api/v1/webhooks/{id}/deliveries?page=&limit=
+			r.Get("/{id}/deliveries", route.GetWebhookDeliveries)
+
+			//POST /api/v1/webhooks/{id}/deliveries/{delivery}/redeliver
+			r.Post("/{id}/deliveries/{delivery}/redeliver", route.RedeliverWebhook)
+		})
+
+		//GET /api/v1/user/history/{page}?limit=&keyword=
 		r.With(auth).
 			Get("/user/history/{page}", route.GetUserVideo)
 
@@ -0,0 +1,132 @@
+package captions
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"transcribify/internal/models"
+)
+
+// Align pairs two tracks of the same video by temporal overlap. Timing of the primary track is kept,
+// every secondary cue is attached to the primary cue it overlaps the most, so secondary text
+// is neither lost nor duplicated when segment boundaries differ. Secondary cue without overlap
+// is attached to the nearest primary cue.
+func Align(primary, secondary []models.Transcription) []models.BilingualPair {
+	var (
+		primaryCues   = Cues(primary)
+		secondaryCues = Cues(secondary)
+		pairs         = make([]models.BilingualPair, len(primaryCues))
+		texts         = make([][]string, len(primaryCues))
+	)
+
+	if len(primaryCues) == 0 {
+		for _, cue := range secondaryCues {
+			pairs = append(pairs, models.BilingualPair{Start: cue.Start, End: cue.End, Secondary: cue.Text})
+		}
+
+		return pairs
+	}
+
+	// cues are ordered and don`t overlap, so the first candidate only moves forward
+	first := 0
+	for _, cue := range secondaryCues {
+		for first < len(primaryCues)-1 && primaryCues[first].End <= cue.Start {
+			first++
+		}
+
+		best, bestOverlap := -1, 0.0
+		for i := first; i < len(primaryCues) && primaryCues[i].Start < cue.End; i++ {
+			if overlap := overlap(primaryCues[i], cue); overlap > bestOverlap {
+				best, bestOverlap = i, overlap
+			}
+		}
+
+		if best == -1 {
+			best = nearest(primaryCues, first, cue)
+		}
+		texts[best] = append(texts[best], cue.Text)
+	}
+
+	for i, cue := range primaryCues {
+		pairs[i] = models.BilingualPair{
+			Start:     cue.Start,
+			End:       cue.End,
+			Primary:   cue.Text,
+			Secondary: strings.Join(texts[i], " "),
+		}
+	}
+
+	return pairs
+}
+
+func overlap(a, b Cue) float64 {
+	start, end := a.Start, a.End
+	if b.Start > start {
+		start = b.Start
+	}
+	if b.End < end {
+		end = b.End
+	}
+
+	return end - start
+}
+
+// nearest returns primary cue at index i or before it closest to the cue in time
+func nearest(primary []Cue, i int, cue Cue) int {
+	if i > 0 && cue.Start-primary[i-1].End < primary[i].Start-cue.End {
+		return i - 1
+	}
+
+	return i
+}
+
+// RenderBilingual writes aligned pairs to w in specified format. Subtitles have primary line above secondary one,
+// text and Markdown interleave primary and secondary paragraphs.
+func RenderBilingual(w io.Writer, format Format, pairs []models.BilingualPair, options Options) error {
+	buf := bufio.NewWriter(w)
+
+	switch format {
+	case SRT, VTT:
+		sep, escape := ",", strings.NewReplacer().Replace
+		if format == VTT {
+			sep, escape = ".", vttEscaper.Replace
+			buf.WriteString("WEBVTT\n\n")
+		}
+
+		for i, pair := range pairs {
+			fmt.Fprintf(buf, "%d\n%s --> %s\n%s\n\n",
+				i+1,
+				Timestamp(pair.Start, sep),
+				Timestamp(pair.End, sep),
+				escape(cueText(pair.Primary+"\n"+pair.Secondary)),
+			)
+		}
+	case Text, Markdown:
+		// Markdown needs trailing spaces for line break
+		primary, secondary := "%s\n", "%s\n"
+		if format == Markdown {
+			primary, secondary = "%s  \n", "*%s*\n"
+		}
+
+		for i, pair := range pairs {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			if options.Timestamps {
+				fmt.Fprintf(buf, "[%s] ", Clock(pair.Start))
+			}
+			text := strings.TrimSpace(pair.Secondary)
+			if text == "" {
+				fmt.Fprintf(buf, "%s\n", strings.TrimSpace(pair.Primary))
+				continue
+			}
+			fmt.Fprintf(buf, primary, strings.TrimSpace(pair.Primary))
+			fmt.Fprintf(buf, secondary, text)
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+
+	return buf.Flush()
+}
@@ -0,0 +1,125 @@
+package captions
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"transcribify/internal/models"
+)
+
+func TestAlign(t *testing.T) {
+	tests := []struct {
+		name      string
+		primary   []models.Transcription
+		secondary []models.Transcription
+		expected  []models.BilingualPair
+	}{
+		{
+			name: "Same boundaries",
+			primary: []models.Transcription{
+				{Subtitle: "hello", Start: 0, Dur: 2},
+				{Subtitle: "world", Start: 2, Dur: 2},
+			},
+			secondary: []models.Transcription{
+				{Subtitle: "hallo", Start: 0, Dur: 2},
+				{Subtitle: "welt", Start: 2, Dur: 2},
+			},
+			expected: []models.BilingualPair{
+				{Start: 0, End: 2, Primary: "hello", Secondary: "hallo"},
+				{Start: 2, End: 4, Primary: "world", Secondary: "welt"},
+			},
+		},
+		{
+			name: "Different boundaries",
+			primary: []models.Transcription{
+				{Subtitle: "one", Start: 0, Dur: 2},
+				{Subtitle: "two", Start: 2, Dur: 2},
+				{Subtitle: "three", Start: 4, Dur: 2},
+			},
+			secondary: []models.Transcription{
+				{Subtitle: "eins", Start: 0, Dur: 1},
+				{Subtitle: "eins-zwei", Start: 1, Dur: 2.5},
+				{Subtitle: "drei", Start: 3.5, Dur: 2.5},
+			},
+			expected: []models.BilingualPair{
+				{Start: 0, End: 2, Primary: "one", Secondary: "eins"},
+				{Start: 2, End: 4, Primary: "two", Secondary: "eins-zwei"},
+				{Start: 4, End: 6, Primary: "three", Secondary: "drei"},
+			},
+		},
+		{
+			name: "Secondary in the gap",
+			primary: []models.Transcription{
+				{Subtitle: "one", Start: 0, Dur: 1},
+				{Subtitle: "two", Start: 10, Dur: 1},
+			},
+			secondary: []models.Transcription{
+				{Subtitle: "near one", Start: 2, Dur: 1},
+				{Subtitle: "near two", Start: 8, Dur: 1},
+				{Subtitle: "after", Start: 20, Dur: 1},
+			},
+			expected: []models.BilingualPair{
+				{Start: 0, End: 1, Primary: "one", Secondary: "near one"},
+				{Start: 10, End: 11, Primary: "two", Secondary: "near two after"},
+			},
+		},
+		{
+			name:    "Empty primary",
+			primary: nil,
+			secondary: []models.Transcription{
+				{Subtitle: "only", Start: 1, Dur: 1},
+			},
+			expected: []models.BilingualPair{
+				{Start: 1, End: 2, Secondary: "only"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Align(tt.primary, tt.secondary))
+		})
+	}
+}
+
+func TestRenderBilingual(t *testing.T) {
+	pairs := []models.BilingualPair{
+		{Start: 0, End: 2, Primary: "hello", Secondary: "hallo"},
+		{Start: 2, End: 4.5, Primary: "a < b", Secondary: ""},
+	}
+
+	tests := []struct {
+		name     string
+		format   Format
+		options  Options
+		expected string
+	}{
+		{
+			name:     "SRT",
+			format:   SRT,
+			expected: "1\n00:00:00,000 --> 00:00:02,000\nhello\nhallo\n\n2\n00:00:02,000 --> 00:00:04,500\na < b\n\n",
+		},
+		{
+			name:     "VTT",
+			format:   VTT,
+			expected: "WEBVTT\n\n1\n00:00:00.000 --> 00:00:02.000\nhello\nhallo\n\n2\n00:00:02.000 --> 00:00:04.500\na &lt; b\n\n",
+		},
+		{
+			name:     "Text with timestamps",
+			format:   Text,
+			options:  Options{Timestamps: true},
+			expected: "[00:00] hello\nhallo\n\n[00:02] a < b\n",
+		},
+		{
+			name:     "Markdown",
+			format:   Markdown,
+			expected: "hello  \n*hallo*\n\na < b\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			assert.NoError(t, RenderBilingual(&sb, tt.format, pairs, tt.options))
+			assert.Equal(t, tt.expected, sb.String())
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package captions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"transcribify/internal/models"
+)
+
+// Format represents file format that transcription can be rendered to.
+type Format string
+
+const (
+	SRT      Format = "srt"
+	VTT      Format = "vtt"
+	Text     Format = "txt"
+	Markdown Format = "md"
+)
+
+const (
+	// DefaultDuration uses for segments without duration when there is no next segment.
+	DefaultDuration = 2.0
+	// MinDuration is the shortest cue that will be rendered.
+	MinDuration = 0.1
+)
+
+var ErrUnknownFormat = errors.New("unknown caption format")
+
+// ParseFormat returns ErrUnknownFormat if format isn`t supported
+func ParseFormat(format string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(format))); f {
+	case SRT, VTT, Text, Markdown:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case SRT:
+		return "application/x-subrip; charset=utf-8"
+	case VTT:
+		return "text/vtt; charset=utf-8"
+	case Text:
+		return "text/plain; charset=utf-8"
+	case Markdown:
+		return "text/markdown; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// Cue is a single caption with resolved start and end time in seconds.
+type Cue struct {
+	Index int
+	Start float64
+	End   float64
+	Text  string
+}
+
+// Cues converts transcription segments to the cues ready for rendering.
+// Segments are ordered by start time, empty segments are dropped,
+// zero-duration segments are extended to the next segment (or DefaultDuration)
+// and overlapping segments are cut at the start of the next one.
+func Cues(transcription []models.Transcription) []Cue {
+	segments := make([]models.Transcription, 0, len(transcription))
+	for _, t := range transcription {
+		if strings.TrimSpace(t.Subtitle) == "" {
+			continue
+		}
+		segments = append(segments, t)
+	}
+
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].Start < segments[j].Start
+	})
+
+	cues := make([]Cue, 0, len(segments))
+	for i, s := range segments {
+		start := math.Max(s.Start, 0)
+		end := start + s.Dur
+
+		// next segment which starts later than current
+		next := -1.0
+		for j := i + 1; j < len(segments); j++ {
+			if segments[j].Start > start {
+				next = segments[j].Start
+				break
+			}
+		}
+
+		if s.Dur <= 0 {
+			end = start + DefaultDuration
+		}
+		if next > 0 && end > next {
+			end = next
+		}
+		if end-start < MinDuration {
+			end = start + MinDuration
+		}
+
+		cues = append(cues, Cue{
+			Index: len(cues) + 1,
+			Start: start,
+			End:   end,
+			Text:  s.Subtitle,
+		})
+	}
+
+	return cues
+}
+
+// Timestamp formats seconds as HH:MM:SS<sep>mmm
+func Timestamp(seconds float64, sep string) string {
+	ms := int64(math.Round(seconds * 1000))
+	if ms < 0 {
+		ms = 0
+	}
+
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d",
+		ms/3_600_000,
+		ms/60_000%60,
+		ms/1000%60,
+		sep,
+		ms%1000,
+	)
+}
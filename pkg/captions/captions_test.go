@@ -0,0 +1,180 @@
+package captions
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"transcribify/internal/models"
+)
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		name     string
+		seconds  float64
+		sep      string
+		expected string
+	}{
+		{name: "Zero", seconds: 0, sep: ",", expected: "00:00:00,000"},
+		{name: "Milliseconds rounding", seconds: 1.2345, sep: ".", expected: "00:00:01.235"},
+		{name: "Hours", seconds: 3723.5, sep: ",", expected: "01:02:03,500"},
+		{name: "Negative", seconds: -1, sep: ",", expected: "00:00:00,000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Timestamp(tt.seconds, tt.sep))
+		})
+	}
+}
+
+func TestCues(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []models.Transcription
+		expected []Cue
+	}{
+		{
+			name: "Overlapping segments",
+			data: []models.Transcription{
+				{Subtitle: "first", Start: 0, Dur: 3},
+				{Subtitle: "second", Start: 2, Dur: 2},
+			},
+			expected: []Cue{
+				{Index: 1, Start: 0, End: 2, Text: "first"},
+				{Index: 2, Start: 2, End: 4, Text: "second"},
+			},
+		},
+		{
+			name: "Zero duration",
+			data: []models.Transcription{
+				{Subtitle: "first", Start: 0, Dur: 0},
+				{Subtitle: "second", Start: 1, Dur: 0},
+			},
+			expected: []Cue{
+				{Index: 1, Start: 0, End: 1, Text: "first"},
+				{Index: 2, Start: 1, End: 1 + DefaultDuration, Text: "second"},
+			},
+		},
+		{
+			name: "Unordered with empty",
+			data: []models.Transcription{
+				{Subtitle: "second", Start: 5, Dur: 1},
+				{Subtitle: " ", Start: 3, Dur: 1},
+				{Subtitle: "first", Start: 1, Dur: 1},
+			},
+			expected: []Cue{
+				{Index: 1, Start: 1, End: 2, Text: "first"},
+				{Index: 2, Start: 5, End: 6, Text: "second"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Cues(tt.data))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	data := []models.Transcription{
+		{Subtitle: "a <b> & c", Start: 0.5, Dur: 1.25},
+		{Subtitle: "line\n\nbreak", Start: 2, Dur: 1},
+	}
+
+	tests := []struct {
+		name     string
+		format   Format
+		expected string
+	}{
+		{
+			name:   "SRT",
+			format: SRT,
+			expected: "1\n00:00:00,500 --> 00:00:01,750\na <b> & c\n\n" +
+				"2\n00:00:02,000 --> 00:00:03,000\nline\nbreak\n\n",
+		},
+		{
+			name:   "VTT",
+			format: VTT,
+			expected: "WEBVTT\n\n" +
+				"1\n00:00:00.500 --> 00:00:01.750\na &lt;b&gt; &amp; c\n\n" +
+				"2\n00:00:02.000 --> 00:00:03.000\nline\nbreak\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+
+			err := Render(&b, tt.format, &models.YTVideo{Transcription: data}, Options{})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, b.String())
+		})
+	}
+}
+
+func TestParagraphs(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []models.Transcription
+		expected []Paragraph
+	}{
+		{
+			name: "Pause after sentence",
+			data: []models.Transcription{
+				{Subtitle: "Hello and", Start: 0, Dur: 1},
+				{Subtitle: "welcome.", Start: 1, Dur: 1},
+				{Subtitle: "Today we", Start: 4, Dur: 1},
+				{Subtitle: "talk", Start: 5.2, Dur: 1},
+			},
+			expected: []Paragraph{
+				{Start: 0, Text: "Hello and welcome."},
+				{Start: 4, Text: "Today we talk"},
+			},
+		},
+		{
+			name: "Short pause inside sentence",
+			data: []models.Transcription{
+				{Subtitle: "no punctuation", Start: 0, Dur: 1},
+				{Subtitle: "here", Start: 3, Dur: 1},
+			},
+			expected: []Paragraph{
+				{Start: 0, Text: "no punctuation here"},
+			},
+		},
+		{
+			name: "Long pause without punctuation",
+			data: []models.Transcription{
+				{Subtitle: "first", Start: 0, Dur: 1},
+				{Subtitle: "second", Start: 10, Dur: 1},
+			},
+			expected: []Paragraph{
+				{Start: 0, Text: "first"},
+				{Start: 10, Text: "second"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Paragraphs(tt.data))
+		})
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	video := &models.YTVideo{
+		Title:       "Title",
+		Description: "About",
+		Thumbnails: []models.Thumbnails{
+			{Url: "small.jpg", Width: 120},
+			{Url: "large.jpg", Width: 1280},
+		},
+		Transcription: []models.Transcription{
+			{Subtitle: "Hello.", Start: 65, Dur: 1},
+		},
+	}
+	var b strings.Builder
+
+	err := WriteMarkdown(&b, video, Options{Timestamps: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "# Title\n\n![Title](large.jpg)\n\n> About\n\n**[01:05]** Hello.\n\n", b.String())
+}
@@ -0,0 +1,80 @@
+package captions
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"transcribify/internal/models"
+)
+
+var vttEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+)
+
+// Render writes video transcription to w in specified format.
+func Render(w io.Writer, format Format, video *models.YTVideo, options Options) error {
+	switch format {
+	case SRT:
+		return WriteSRT(w, video.Transcription)
+	case VTT:
+		return WriteVTT(w, video.Transcription)
+	case Text:
+		return WriteText(w, video.Transcription, options)
+	case Markdown:
+		return WriteMarkdown(w, video, options)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// WriteSRT writes transcription in SubRip format
+func WriteSRT(w io.Writer, transcription []models.Transcription) error {
+	buf := bufio.NewWriter(w)
+
+	for _, cue := range Cues(transcription) {
+		fmt.Fprintf(buf, "%d\n%s --> %s\n%s\n\n",
+			cue.Index,
+			Timestamp(cue.Start, ","),
+			Timestamp(cue.End, ","),
+			cueText(cue.Text),
+		)
+	}
+
+	return buf.Flush()
+}
+
+// WriteVTT writes transcription in WebVTT format
+func WriteVTT(w io.Writer, transcription []models.Transcription) error {
+	buf := bufio.NewWriter(w)
+	buf.WriteString("WEBVTT\n\n")
+
+	for _, cue := range Cues(transcription) {
+		fmt.Fprintf(buf, "%d\n%s --> %s\n%s\n\n",
+			cue.Index,
+			Timestamp(cue.Start, "."),
+			Timestamp(cue.End, "."),
+			vttEscaper.Replace(cueText(cue.Text)),
+		)
+	}
+
+	return buf.Flush()
+}
+
+// cueText removes blank lines, because blank line terminates the cue.
+func cueText(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	res := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		res = append(res, strings.ReplaceAll(line, "-->", "->"))
+	}
+
+	return strings.Join(res, "\n")
+}
@@ -0,0 +1,182 @@
+package captions
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"transcribify/internal/models"
+)
+
+const (
+	// SentencePause is the gap (in seconds) after a finished sentence that starts new paragraph.
+	SentencePause = 1.5
+	// LongPause is the gap (in seconds) that starts new paragraph even inside unfinished sentence.
+	LongPause = 4.0
+	// MaxSentences per paragraph when the speaker makes no pauses.
+	MaxSentences = 6
+)
+
+// Options configures plain-text and Markdown rendering.
+type Options struct {
+	// Timestamps adds [mm:ss] before each paragraph
+	Timestamps bool
+}
+
+// Paragraph is a group of merged segments.
+type Paragraph struct {
+	Start float64
+	Text  string
+}
+
+// Paragraphs merges transcription segments into paragraphs using pauses between
+// segments (gap between Start+Dur and the next Start) and sentence punctuation.
+func Paragraphs(transcription []models.Transcription) []Paragraph {
+	segments := make([]models.Transcription, 0, len(transcription))
+	for _, t := range transcription {
+		if strings.TrimSpace(t.Subtitle) == "" {
+			continue
+		}
+		segments = append(segments, t)
+	}
+
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].Start < segments[j].Start
+	})
+
+	var (
+		paragraphs []Paragraph
+		words      []string
+		start      float64
+		sentences  int
+	)
+
+	flush := func() {
+		if len(words) == 0 {
+			return
+		}
+		paragraphs = append(paragraphs, Paragraph{Start: start, Text: strings.Join(words, " ")})
+		words = nil
+		sentences = 0
+	}
+
+	for i, s := range segments {
+		if len(words) == 0 {
+			start = s.Start
+		}
+
+		words = append(words, strings.Fields(s.Subtitle)...)
+
+		finished := endsSentence(words[len(words)-1])
+		if finished {
+			sentences++
+		}
+
+		if i == len(segments)-1 {
+			break
+		}
+
+		gap := segments[i+1].Start - (s.Start + s.Dur)
+
+		switch {
+		case gap >= LongPause:
+			flush()
+		case finished && gap >= SentencePause:
+			flush()
+		case finished && sentences >= MaxSentences:
+			flush()
+		}
+	}
+	flush()
+
+	return paragraphs
+}
+
+// WriteText writes transcription as plain text paragraphs
+func WriteText(w io.Writer, transcription []models.Transcription, options Options) error {
+	buf := bufio.NewWriter(w)
+
+	for i, p := range Paragraphs(transcription) {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		if options.Timestamps {
+			fmt.Fprintf(buf, "[%s] ", Clock(p.Start))
+		}
+		buf.WriteString(p.Text)
+		buf.WriteString("\n")
+	}
+
+	return buf.Flush()
+}
+
+// WriteMarkdown writes video title, thumbnail and description followed by transcription paragraphs
+func WriteMarkdown(w io.Writer, video *models.YTVideo, options Options) error {
+	buf := bufio.NewWriter(w)
+
+	if video.Title != "" {
+		fmt.Fprintf(buf, "# %s\n\n", video.Title)
+	}
+
+	if thumb, ok := largestThumbnail(video.Thumbnails); ok {
+		fmt.Fprintf(buf, "![%s](%s)\n\n", video.Title, thumb.Url)
+	}
+
+	if description := strings.TrimSpace(video.Description); description != "" {
+		for _, line := range strings.Split(description, "\n") {
+			fmt.Fprintf(buf, "> %s\n", strings.TrimRight(line, "\r "))
+		}
+		buf.WriteString("\n")
+	}
+
+	for _, p := range Paragraphs(video.Transcription) {
+		if options.Timestamps {
+			fmt.Fprintf(buf, "**[%s]** ", Clock(p.Start))
+		}
+		buf.WriteString(p.Text)
+		buf.WriteString("\n\n")
+	}
+
+	return buf.Flush()
+}
+
+// Clock formats seconds as mm:ss or h:mm:ss for long videos
+func Clock(seconds float64) string {
+	s := int64(math.Max(math.Floor(seconds), 0))
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+
+	return fmt.Sprintf("%02d:%02d", s/60, s%60)
+}
+
+func endsSentence(word string) bool {
+	word = strings.TrimRight(word, `"')]»`)
+	if word == "" {
+		return false
+	}
+
+	switch word[len(word)-1] {
+	case '.', '!', '?':
+		return true
+	}
+
+	return strings.HasSuffix(word, "…")
+}
+
+func largestThumbnail(thumbnails []models.Thumbnails) (models.Thumbnails, bool) {
+	if len(thumbnails) == 0 {
+		return models.Thumbnails{}, false
+	}
+
+	largest := thumbnails[0]
+	for _, t := range thumbnails[1:] {
+		if t.Width > largest.Width {
+			largest = t
+		}
+	}
+
+	return largest, largest.Url != ""
+}
@@ -0,0 +1,144 @@
+package chapters
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"transcribify/internal/models"
+)
+
+// Source of the chapters
+const (
+	Description = "description"
+	Transcript  = "transcript"
+)
+
+var (
+	// "00:00 Intro", "- 3:15 - Setup", "[1:02:03] Outro"
+	timestampFirst = regexp.MustCompile(`^[\s\-–—•*▶►]*[(\[]?((?:\d{1,2}:)?\d{1,2}:\d{2})[)\]]?[\s\-–—:|.]*(.*?)\s*$`)
+	// "Intro - 00:00", "Setup (3:15)"
+	timestampLast = regexp.MustCompile(`^[\s\-–—•*▶►]*(.+?)[\s\-–—:|]*[(\[]?((?:\d{1,2}:)?\d{1,2}:\d{2})[)\]]?\s*$`)
+)
+
+// Extract returns chapters from video description timestamps
+// or falls back to topic segmentation of the transcript in the language.
+func Extract(video *models.YTVideo, language string) ([]models.Chapter, string) {
+	end := Duration(video)
+
+	if chapters := FromDescription(video.Description, video.Transcription, end); len(chapters) > 0 {
+		return chapters, Description
+	}
+
+	return Segment(video.Transcription, end, language, DefaultOptions), Transcript
+}
+
+// FromDescription parses description lines starting or ending with timestamp.
+// Like YouTube, requires at least two chapters in ascending order, the first one starting at 0:00.
+// Returns nil if description doesn`t contain chapters.
+func FromDescription(description string, transcription []models.Transcription, end float64) []models.Chapter {
+	var chapters []models.Chapter
+
+	for _, line := range strings.Split(description, "\n") {
+		start, title, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+
+		if len(chapters) == 0 && start != 0 {
+			continue
+		}
+		if len(chapters) > 0 && start <= chapters[len(chapters)-1].Start {
+			return nil
+		}
+
+		chapters = append(chapters, models.Chapter{Title: title, Start: start})
+	}
+
+	if len(chapters) < 2 {
+		return nil
+	}
+
+	return finish(chapters, transcription, end)
+}
+
+// Duration returns video length or the end of the last transcription segment
+func Duration(video *models.YTVideo) float64 {
+	var end float64
+	for _, t := range video.Transcription {
+		end = math.Max(end, t.Start+t.Dur)
+	}
+
+	if length, err := strconv.ParseFloat(video.LengthInSeconds, 64); err == nil && length > end {
+		return length
+	}
+
+	return end
+}
+
+// ParseTimestamp parses h:mm:ss or m:ss into seconds
+func ParseTimestamp(timestamp string) (float64, bool) {
+	parts := strings.Split(timestamp, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, false
+	}
+
+	var seconds int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (i > 0 && n > 59) {
+			return 0, false
+		}
+		seconds = seconds*60 + n
+	}
+
+	return float64(seconds), true
+}
+
+func parseLine(line string) (float64, string, bool) {
+	var timestamp, title string
+
+	if m := timestampFirst.FindStringSubmatch(line); m != nil && m[2] != "" {
+		timestamp, title = m[1], m[2]
+	} else if m := timestampLast.FindStringSubmatch(line); m != nil {
+		timestamp, title = m[2], m[1]
+	} else {
+		return 0, "", false
+	}
+
+	start, ok := ParseTimestamp(timestamp)
+	if !ok {
+		return 0, "", false
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return 0, "", false
+	}
+
+	return start, title, true
+}
+
+// finish sets chapters end and segments range
+func finish(chapters []models.Chapter, transcription []models.Transcription, end float64) []models.Chapter {
+	for i := range chapters {
+		if i+1 < len(chapters) {
+			chapters[i].End = chapters[i+1].Start
+		} else {
+			chapters[i].End = math.Max(end, chapters[i].Start)
+		}
+
+		chapters[i].FirstSegment, chapters[i].LastSegment = -1, -1
+		for j, t := range transcription {
+			if t.Start < chapters[i].Start || (t.Start >= chapters[i].End && i+1 < len(chapters)) {
+				continue
+			}
+			if chapters[i].FirstSegment == -1 {
+				chapters[i].FirstSegment = j
+			}
+			chapters[i].LastSegment = j
+		}
+	}
+
+	return chapters
+}
@@ -0,0 +1,130 @@
+package chapters
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"transcribify/internal/models"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+		ok    bool
+	}{
+		{input: "00:00", want: 0, ok: true},
+		{input: "3:15", want: 195, ok: true},
+		{input: "1:02:03", want: 3723, ok: true},
+		{input: "1:60", ok: false},
+		{input: "15", ok: false},
+	}
+
+	for _, test := range tests {
+		got, ok := ParseTimestamp(test.input)
+		assert.Equal(t, test.ok, ok, test.input)
+		assert.Equal(t, test.want, got, test.input)
+	}
+}
+
+func TestFromDescription(t *testing.T) {
+	transcription := []models.Transcription{
+		{Subtitle: "hello", Start: 0, Dur: 5},
+		{Subtitle: "setup", Start: 200, Dur: 5},
+		{Subtitle: "bye", Start: 3730, Dur: 5},
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		want        []models.Chapter
+	}{
+		{
+			name:        "timestamp first",
+			description: "Great video\n\n00:00 Intro\n- 3:15 - Setup\n[1:02:03] Outro\nThanks for watching",
+			want: []models.Chapter{
+				{Title: "Intro", Start: 0, End: 195, FirstSegment: 0, LastSegment: 0},
+				{Title: "Setup", Start: 195, End: 3723, FirstSegment: 1, LastSegment: 1},
+				{Title: "Outro", Start: 3723, End: 3735, FirstSegment: 2, LastSegment: 2},
+			},
+		},
+		{
+			name:        "timestamp last",
+			description: "Intro - 0:00\nSetup (3:15)",
+			want: []models.Chapter{
+				{Title: "Intro", Start: 0, End: 195, FirstSegment: 0, LastSegment: 0},
+				{Title: "Setup", Start: 195, End: 3735, FirstSegment: 1, LastSegment: 2},
+			},
+		},
+		{
+			name:        "not from zero",
+			description: "1:00 Intro\n2:00 Setup",
+		},
+		{
+			name:        "single",
+			description: "0:00 Intro",
+		},
+		{
+			name:        "not ascending",
+			description: "0:00 Intro\n5:00 Setup\n3:00 Outro",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, FromDescription(test.description, transcription, 3735))
+		})
+	}
+}
+
+func TestSegment(t *testing.T) {
+	var transcription []models.Transcription
+	for i, topic := range []string{
+		"docker container image registry",
+		"postgres database query index",
+		"kubernetes cluster pod deployment",
+	} {
+		for j := 0; j < 30; j++ {
+			transcription = append(transcription, models.Transcription{
+				Subtitle: "so " + topic,
+				Start:    float64(i*300 + j*10),
+				Dur:      10,
+			})
+		}
+	}
+
+	chapters := Segment(transcription, 900, "en", DefaultOptions)
+
+	assert.Len(t, chapters, 3)
+	for i, c := range chapters {
+		assert.Equal(t, i*30, c.FirstSegment)
+		assert.Equal(t, i*30+29, c.LastSegment)
+		assert.Equal(t, float64(i*300), c.Start)
+	}
+	assert.Equal(t, float64(900), chapters[2].End)
+	assert.True(t, strings.HasPrefix(chapters[1].Title, "Database") || strings.HasPrefix(chapters[1].Title, "Index") ||
+		strings.HasPrefix(chapters[1].Title, "Postgres") || strings.HasPrefix(chapters[1].Title, "Query"))
+
+	short := Segment(transcription[:5], 50, "en", DefaultOptions)
+	assert.Len(t, short, 1)
+	assert.Equal(t, 4, short[0].LastSegment)
+}
+
+func TestExtract(t *testing.T) {
+	video := &models.YTVideo{
+		Description:     "0:00 Intro\n0:10 Main",
+		LengthInSeconds: "20",
+		Transcription:   []models.Transcription{{Subtitle: "hi", Start: 0, Dur: 5}},
+	}
+
+	chapters, source := Extract(video, "en")
+	assert.Equal(t, Description, source)
+	assert.Len(t, chapters, 2)
+	assert.Equal(t, float64(20), chapters[1].End)
+	assert.Equal(t, -1, chapters[1].FirstSegment)
+
+	video.Description = ""
+	chapters, source = Extract(video, "en")
+	assert.Equal(t, Transcript, source)
+	assert.Len(t, chapters, 1)
+}
@@ -0,0 +1,248 @@
+package chapters
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"transcribify/internal/models"
+	"transcribify/pkg/keywords"
+	"unicode"
+)
+
+// Options of the transcript topic segmentation
+type Options struct {
+	// Window is the number of segments compared on each side of the gap
+	Window int
+	// MinDuration of the chapter in seconds
+	MinDuration float64
+	// MaxChapters limits the number of chapters
+	MaxChapters int
+	// TitleWords is the number of keywords in the generated chapter title
+	TitleWords int
+}
+
+var DefaultOptions = Options{
+	Window:      10,
+	MinDuration: 60,
+	MaxChapters: 15,
+	TitleWords:  3,
+}
+
+// Segment splits transcription into chapters using lexical cohesion (TextTiling):
+// word vectors of the windows on both sides of every gap between segments are compared,
+// and gaps in the deepest valleys of similarity become chapter boundaries.
+// Chapter titles are made of the most specific words of the chapter.
+func Segment(transcription []models.Transcription, end float64, language string, options Options) []models.Chapter {
+	if len(transcription) == 0 {
+		return []models.Chapter{}
+	}
+
+	stopwords := keywords.Stopwords(language)
+
+	bags := make([]map[string]int, len(transcription))
+	for i, t := range transcription {
+		bags[i] = bag(t.Subtitle, stopwords)
+	}
+
+	boundaries := boundaries(transcription, bags, end, options)
+
+	chapters := make([]models.Chapter, 0, len(boundaries)+1)
+	first := 0
+	for _, b := range append(boundaries, len(transcription)) {
+		chapters = append(chapters, models.Chapter{Start: transcription[first].Start})
+		first = b
+	}
+	chapters[0].Start = 0
+
+	chapters = finish(chapters, transcription, end)
+	titles(chapters, bags, options.TitleWords)
+
+	return chapters
+}
+
+// boundaries returns indexes of segments starting new chapters in ascending order
+func boundaries(transcription []models.Transcription, bags []map[string]int, end float64, options Options) []int {
+	n := len(transcription)
+	if options.Window < 1 || n < 2*options.Window {
+		return nil
+	}
+
+	// similarity of the gap before segment g
+	similarity := make([]float64, n)
+	for g := options.Window; g <= n-options.Window; g++ {
+		similarity[g] = cosine(merge(bags[g-options.Window:g]), merge(bags[g:g+options.Window]))
+	}
+
+	var (
+		gaps   []int
+		depths = make([]float64, n)
+		sum    float64
+		sumSq  float64
+	)
+	for g := options.Window; g <= n-options.Window; g++ {
+		left, right := similarity[g], similarity[g]
+		for i := g - 1; i >= options.Window && similarity[i] >= left; i-- {
+			left = similarity[i]
+		}
+		for i := g + 1; i <= n-options.Window && similarity[i] >= right; i++ {
+			right = similarity[i]
+		}
+
+		depths[g] = left + right - 2*similarity[g]
+		gaps = append(gaps, g)
+		sum += depths[g]
+		sumSq += depths[g] * depths[g]
+	}
+
+	mean := sum / float64(len(gaps))
+	threshold := mean - math.Sqrt(math.Max(sumSq/float64(len(gaps))-mean*mean, 0))/2
+
+	var candidates []int
+	for _, g := range gaps {
+		if depths[g] > 0 && depths[g] > threshold &&
+			depths[g] >= depths[g-1] && (g+1 >= n || depths[g] >= depths[g+1]) {
+			candidates = append(candidates, g)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return depths[candidates[i]] > depths[candidates[j]]
+	})
+
+	var chosen []int
+	for _, g := range candidates {
+		if options.MaxChapters > 0 && len(chosen)+1 >= options.MaxChapters {
+			break
+		}
+
+		start := transcription[g].Start
+		if start < options.MinDuration || end-start < options.MinDuration {
+			continue
+		}
+
+		near := false
+		for _, c := range chosen {
+			if math.Abs(transcription[c].Start-start) < options.MinDuration {
+				near = true
+				break
+			}
+		}
+		if !near {
+			chosen = append(chosen, g)
+		}
+	}
+
+	sort.Ints(chosen)
+
+	return chosen
+}
+
+// titles sets titles of chapters from words frequent in the chapter and rare in the others
+func titles(chapters []models.Chapter, bags []map[string]int, words int) {
+	var (
+		tfs = make([]map[string]int, len(chapters))
+		df  = make(map[string]int)
+	)
+
+	for i, c := range chapters {
+		if c.FirstSegment < 0 {
+			tfs[i] = map[string]int{}
+			continue
+		}
+
+		tfs[i] = merge(bags[c.FirstSegment : c.LastSegment+1])
+		for w := range tfs[i] {
+			df[w]++
+		}
+	}
+
+	for i := range chapters {
+		type scored struct {
+			word  string
+			score float64
+		}
+
+		terms := make([]scored, 0, len(tfs[i]))
+		for w, tf := range tfs[i] {
+			idf := math.Log(float64(len(chapters)+1) / float64(df[w]))
+			terms = append(terms, scored{word: w, score: float64(tf) * idf})
+		}
+		sort.Slice(terms, func(a, b int) bool {
+			if terms[a].score != terms[b].score {
+				return terms[a].score > terms[b].score
+			}
+			return terms[a].word < terms[b].word
+		})
+
+		title := make([]string, 0, words)
+		for _, t := range terms {
+			if len(title) == words {
+				break
+			}
+			title = append(title, t.word)
+		}
+
+		if len(title) == 0 {
+			chapters[i].Title = "Part " + strconv.Itoa(i+1)
+			continue
+		}
+
+		title[0] = capitalize(title[0])
+		chapters[i].Title = strings.Join(title, ", ")
+	}
+}
+
+func bag(text string, stopwords map[string]bool) map[string]int {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	res := make(map[string]int, len(words))
+	for _, w := range words {
+		w = strings.Trim(w, "'")
+		if len([]rune(w)) < 3 || stopwords[w] {
+			continue
+		}
+		res[w]++
+	}
+
+	return res
+}
+
+func merge(bags []map[string]int) map[string]int {
+	res := make(map[string]int)
+	for _, b := range bags {
+		for w, n := range b {
+			res[w] += n
+		}
+	}
+
+	return res
+}
+
+func cosine(a, b map[string]int) float64 {
+	var dot, normA, normB float64
+	for w, n := range a {
+		normA += float64(n * n)
+		if m, ok := b[w]; ok {
+			dot += float64(n * m)
+		}
+	}
+	for _, m := range b {
+		normB += float64(m * m)
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / math.Sqrt(normA*normB)
+}
+
+func capitalize(word string) string {
+	runes := []rune(word)
+	runes[0] = unicode.ToUpper(runes[0])
+
+	return string(runes)
+}
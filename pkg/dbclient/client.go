@@ -4,26 +4,33 @@ import (
 	"context"
 	"fmt"
 	"github.com/cenkalti/backoff/v4"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 	"time"
 	"transcribify/internal/config"
 	"transcribify/pkg/logging"
 )
 
-func NewClient(ctx context.Context) (client *pgx.Conn, err error) {
+// NewClient returns connections pool, it is safe for concurrent use
+func NewClient(ctx context.Context) (client *pgxpool.Pool, err error) {
 	configuration := config.DB()
 	dsn := GetDSN(configuration)
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	err = doWithAttempts(ctx, func() error {
 
-		client, err = pgx.Connect(ctx, dsn)
+		client, err = pgxpool.New(ctx, dsn)
 		if err != nil {
 			return err
 		}
 
-		return client.Ping(ctx)
+		if err = client.Ping(ctx); err != nil {
+			client.Close()
+
+			return err
+		}
+
+		return nil
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,143 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Embedder converts texts to vectors of the same dimensions. Vectors are L2 normalized,
+// so dot product is cosine similarity.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	Dimensions() int
+	// Name identifies vector space, vectors of different embedders aren`t comparable
+	Name() string
+}
+
+// DefaultDimensions of Hashing embedder
+const DefaultDimensions = 256
+
+// Hashing is a deterministic embedder without model. Words, word bigrams and character trigrams
+// are hashed into vector dimensions with random sign (feature hashing), so texts sharing words
+// and word forms are close. Stopwords are skipped.
+type Hashing struct {
+	dimensions int
+	stopwords  map[string]bool
+}
+
+func NewHashing(dimensions int, stopwords map[string]bool) *Hashing {
+	if dimensions < 1 {
+		dimensions = DefaultDimensions
+	}
+	if stopwords == nil {
+		stopwords = map[string]bool{}
+	}
+
+	return &Hashing{dimensions: dimensions, stopwords: stopwords}
+}
+
+func (h *Hashing) Dimensions() int {
+	return h.dimensions
+}
+
+func (h *Hashing) Name() string {
+	return fmt.Sprintf("hashing-%d", h.dimensions)
+}
+
+func (h *Hashing) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = h.embed(text)
+	}
+
+	return vectors, nil
+}
+
+func (h *Hashing) embed(text string) []float32 {
+	var (
+		vector = make([]float32, h.dimensions)
+		words  = make([]string, 0)
+	)
+
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !h.stopwords[w] {
+			words = append(words, w)
+		}
+	}
+
+	for i, w := range words {
+		h.add(vector, "w:"+w, 1)
+
+		if i > 0 {
+			h.add(vector, "b:"+words[i-1]+" "+w, 0.5)
+		}
+
+		runes := []rune("#" + w + "#")
+		for j := 0; j+3 <= len(runes); j++ {
+			h.add(vector, "t:"+string(runes[j:j+3]), 0.25)
+		}
+	}
+
+	return Normalize(vector)
+}
+
+func (h *Hashing) add(vector []float32, feature string, weight float32) {
+	hash := fnv.New64a()
+	hash.Write([]byte(feature)) //nolint:errcheck
+	sum := hash.Sum64()
+
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	vector[sum%uint64(len(vector))] += weight
+}
+
+// Normalize scales vector to unit length in place. Zero vector is returned as is.
+func Normalize(vector []float32) []float32 {
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return vector
+	}
+
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range vector {
+		vector[i] *= scale
+	}
+
+	return vector
+}
+
+// Dot returns dot product of vectors of the same length
+func Dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+
+	return sum
+}
+
+// Mean returns normalized mean of vectors
+func Mean(vectors [][]float32) []float32 {
+	if len(vectors) == 0 {
+		return nil
+	}
+
+	mean := make([]float32, len(vectors[0]))
+	for _, v := range vectors {
+		for i := range v {
+			mean[i] += v[i]
+		}
+	}
+
+	return Normalize(mean)
+}
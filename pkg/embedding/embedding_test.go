@@ -0,0 +1,118 @@
+package embedding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+)
+
+func TestHashing_Embed(t *testing.T) {
+	embedder := NewHashing(128, map[string]bool{"the": true})
+
+	vectors, err := embedder.Embed(context.Background(), []string{
+		"the pricing of the product",
+		"product prices",
+		"kubernetes cluster deployment",
+		"",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, vectors, 4)
+	assert.Len(t, vectors[0], 128)
+
+	assert.InDelta(t, 1, Dot(vectors[0], vectors[0]), 1e-5)
+	assert.Greater(t, Dot(vectors[0], vectors[1]), Dot(vectors[0], vectors[2]))
+	assert.Equal(t, float32(0), Dot(vectors[3], vectors[3]))
+
+	again, _ := embedder.Embed(context.Background(), []string{"the pricing of the product"})
+	assert.Equal(t, vectors[0], again[0])
+	assert.Equal(t, "hashing-128", embedder.Name())
+}
+
+func TestHTTP_Embed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/embeddings", r.URL.Path)
+
+		var req embeddingRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []string{"a", "b"}, req.Input)
+
+		// reversed order, embedder uses index
+		fmt.Fprint(w, `{"data":[{"index":1,"embedding":[0,2]},{"index":0,"embedding":[3,4]}]}`)
+	}))
+	defer server.Close()
+
+	embedder := NewHTTP(server.Client(), server.URL, "", "stub", 2)
+
+	vectors, err := embedder.Embed(context.Background(), []string{"a", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{0.6, 0.8}, {0, 1}}, vectors)
+
+	_, err = NewHTTP(server.Client(), server.URL, "", "stub", 3).Embed(context.Background(), []string{"a", "b"})
+	assert.Error(t, err)
+}
+
+func TestHNSW_Search(t *testing.T) {
+	var (
+		rng     = rand.New(rand.NewSource(42))
+		graph   = NewHNSW(8, 64, 32)
+		vectors = make([][]float32, 1000)
+	)
+
+	random := func() []float32 {
+		v := make([]float32, 16)
+		for i := range v {
+			v[i] = float32(rng.NormFloat64())
+		}
+		return Normalize(v)
+	}
+
+	for i := range vectors {
+		vectors[i] = random()
+		assert.Equal(t, i, graph.Add(vectors[i]))
+	}
+	assert.Equal(t, len(vectors), graph.Len())
+
+	var found, total int
+	for q := 0; q < 50; q++ {
+		query := random()
+
+		exact := make([]Neighbor, len(vectors))
+		for i, v := range vectors {
+			exact[i] = Neighbor{ID: i, Score: Dot(query, v)}
+		}
+		sort.Slice(exact, func(i, j int) bool { return exact[i].Score > exact[j].Score })
+
+		result := graph.Search(query, 10)
+		assert.Len(t, result, 10)
+		assert.True(t, sort.SliceIsSorted(result, func(i, j int) bool { return result[i].Score > result[j].Score }))
+
+		ids := make(map[int]bool)
+		for _, n := range result {
+			ids[n.ID] = true
+		}
+		for _, n := range exact[:10] {
+			if ids[n.ID] {
+				found++
+			}
+			total++
+		}
+	}
+
+	recall := float64(found) / float64(total)
+	assert.Greater(t, recall, 0.9, "recall %.2f", recall)
+	assert.Empty(t, NewHNSW(0, 0, 0).Search(vectors[0], 10))
+}
+
+func TestMean(t *testing.T) {
+	mean := Mean([][]float32{{1, 0}, {0, 1}})
+	assert.InDelta(t, 1/math.Sqrt2, mean[0], 1e-6)
+	assert.InDelta(t, 1/math.Sqrt2, mean[1], 1e-6)
+	assert.Nil(t, Mean(nil))
+}
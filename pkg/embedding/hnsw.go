@@ -0,0 +1,257 @@
+package embedding
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+const (
+	// DefaultM is the number of neighbors of the node on the upper layers, the ground layer keeps 2*M
+	DefaultM = 16
+	// DefaultEfConstruction is the size of dynamic candidate list while inserting
+	DefaultEfConstruction = 100
+	// DefaultEfSearch is the minimal size of dynamic candidate list while searching
+	DefaultEfSearch = 64
+)
+
+// Neighbor is a node found by HNSW.Search. Score is cosine similarity.
+type Neighbor struct {
+	ID    int
+	Score float32
+}
+
+// HNSW is an in-memory Hierarchical Navigable Small World graph for approximate nearest neighbor search
+// over normalized vectors. Node ids are assigned sequentially from 0. Nodes can`t be deleted,
+// callers skip stale nodes themselves.
+type HNSW struct {
+	mu sync.RWMutex
+
+	m              int
+	efConstruction int
+	efSearch       int
+	levelMult      float64
+	rng            *rand.Rand
+
+	vectors [][]float32
+	// links of the node on every of its levels
+	links    [][][]int32
+	entry    int
+	maxLevel int
+}
+
+func NewHNSW(m, efConstruction, efSearch int) *HNSW {
+	if m < 2 {
+		m = DefaultM
+	}
+	if efConstruction < m {
+		efConstruction = DefaultEfConstruction
+	}
+	if efSearch < 1 {
+		efSearch = DefaultEfSearch
+	}
+
+	return &HNSW{
+		m:              m,
+		efConstruction: efConstruction,
+		efSearch:       efSearch,
+		levelMult:      1 / math.Log(float64(m)),
+		rng:            rand.New(rand.NewSource(1)), //nolint:gosec
+		entry:          -1,
+	}
+}
+
+func (h *HNSW) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.vectors)
+}
+
+// Vector returns vector of the node
+func (h *HNSW) Vector(id int) []float32 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.vectors[id]
+}
+
+// Add inserts vector and returns its node id
+func (h *HNSW) Add(vector []float32) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := len(h.vectors)
+	level := int(math.Floor(-math.Log(1-h.rng.Float64()) * h.levelMult))
+
+	h.vectors = append(h.vectors, vector)
+	h.links = append(h.links, make([][]int32, level+1))
+
+	if h.entry == -1 {
+		h.entry, h.maxLevel = id, level
+		return id
+	}
+
+	ep := h.entry
+	for l := h.maxLevel; l > level; l-- {
+		ep = h.greedy(vector, ep, l)
+	}
+
+	top := level
+	if h.maxLevel < top {
+		top = h.maxLevel
+	}
+
+	for l := top; l >= 0; l-- {
+		found := h.searchLayer(vector, ep, h.efConstruction, l)
+
+		neighbors := found
+		if len(neighbors) > h.m {
+			neighbors = neighbors[:h.m]
+		}
+
+		h.links[id][l] = make([]int32, 0, len(neighbors))
+		for _, n := range neighbors {
+			h.links[id][l] = append(h.links[id][l], int32(n.ID))
+			h.connect(n.ID, id, l)
+		}
+
+		ep = found[0].ID
+	}
+
+	if level > h.maxLevel {
+		h.entry, h.maxLevel = id, level
+	}
+
+	return id
+}
+
+// Search returns at most k nodes nearest to the query ordered by descending score
+func (h *HNSW) Search(query []float32, k int) []Neighbor {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.entry == -1 || k < 1 {
+		return nil
+	}
+
+	ep := h.entry
+	for l := h.maxLevel; l > 0; l-- {
+		ep = h.greedy(query, ep, l)
+	}
+
+	ef := h.efSearch
+	if ef < k {
+		ef = k
+	}
+
+	found := h.searchLayer(query, ep, ef, 0)
+	if len(found) > k {
+		found = found[:k]
+	}
+
+	return found
+}
+
+// connect adds link from node to neighbor and keeps the closest links if there are too many
+func (h *HNSW) connect(node, neighbor, level int) {
+	links := append(h.links[node][level], int32(neighbor))
+
+	limit := h.m
+	if level == 0 {
+		limit = 2 * h.m
+	}
+
+	if len(links) > limit {
+		sort.Slice(links, func(i, j int) bool {
+			return Dot(h.vectors[node], h.vectors[links[i]]) > Dot(h.vectors[node], h.vectors[links[j]])
+		})
+		links = links[:limit]
+	}
+
+	h.links[node][level] = links
+}
+
+// greedy walks to the closest node on the level
+func (h *HNSW) greedy(query []float32, ep int, level int) int {
+	best := Dot(query, h.vectors[ep])
+
+	for changed := true; changed; {
+		changed = false
+		for _, n := range h.links[ep][level] {
+			if score := Dot(query, h.vectors[n]); score > best {
+				best, ep, changed = score, int(n), true
+			}
+		}
+	}
+
+	return ep
+}
+
+// searchLayer returns ef nodes closest to the query on the level ordered by descending score
+func (h *HNSW) searchLayer(query []float32, ep int, ef int, level int) []Neighbor {
+	var (
+		visited    = map[int]bool{ep: true}
+		first      = Neighbor{ID: ep, Score: Dot(query, h.vectors[ep])}
+		candidates = &neighbors{best: true, items: []Neighbor{first}}
+		results    = &neighbors{items: []Neighbor{first}}
+	)
+
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(Neighbor)
+		if results.Len() >= ef && c.Score < results.items[0].Score {
+			break
+		}
+
+		for _, n := range h.links[c.ID][level] {
+			if visited[int(n)] {
+				continue
+			}
+			visited[int(n)] = true
+
+			score := Dot(query, h.vectors[n])
+			if results.Len() < ef || score > results.items[0].Score {
+				heap.Push(candidates, Neighbor{ID: int(n), Score: score})
+				heap.Push(results, Neighbor{ID: int(n), Score: score})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	found := results.items
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Score > found[j].Score
+	})
+
+	return found
+}
+
+// neighbors is a heap with the best score on top if best is true, otherwise with the worst score on top
+type neighbors struct {
+	best  bool
+	items []Neighbor
+}
+
+func (n *neighbors) Len() int { return len(n.items) }
+
+func (n *neighbors) Less(i, j int) bool {
+	if n.best {
+		return n.items[i].Score > n.items[j].Score
+	}
+	return n.items[i].Score < n.items[j].Score
+}
+
+func (n *neighbors) Swap(i, j int) { n.items[i], n.items[j] = n.items[j], n.items[i] }
+
+func (n *neighbors) Push(x any) { n.items = append(n.items, x.(Neighbor)) }
+
+func (n *neighbors) Pop() any {
+	last := n.items[len(n.items)-1]
+	n.items = n.items[:len(n.items)-1]
+
+	return last
+}
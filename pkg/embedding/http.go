@@ -0,0 +1,112 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"transcribify/pkg/llm"
+)
+
+// HTTP uses OpenAI-compatible `/embeddings` API
+type HTTP struct {
+	client     *http.Client
+	baseURL    string
+	apiKey     string
+	model      string
+	dimensions int
+}
+
+func NewHTTP(client *http.Client, baseURL, apiKey, model string, dimensions int) *HTTP {
+	if baseURL == "" {
+		baseURL = llm.DefaultBaseURL
+	}
+
+	return &HTTP{
+		client:     client,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		model:      model,
+		dimensions: dimensions,
+	}
+}
+
+func (h *HTTP) Dimensions() int {
+	return h.dimensions
+}
+
+func (h *HTTP) Name() string {
+	return "http-" + h.model
+}
+
+type (
+	embeddingRequest struct {
+		Model string   `json:"model"`
+		Input []string `json:"input"`
+	}
+
+	embeddingResponse struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+)
+
+func (h *HTTP) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(embeddingRequest{Model: h.model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	response, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(response.Body, 64<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, &llm.APIError{Status: response.StatusCode, Message: string(raw)}
+	}
+
+	var embeddings embeddingResponse
+	if err = json.Unmarshal(raw, &embeddings); err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range embeddings.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding index %d out of range", d.Index)
+		}
+		if len(d.Embedding) != h.dimensions {
+			return nil, fmt.Errorf("embedding has %d dimensions, expected %d", len(d.Embedding), h.dimensions)
+		}
+		vectors[d.Index] = Normalize(d.Embedding)
+	}
+
+	for i, v := range vectors {
+		if v == nil {
+			return nil, fmt.Errorf("no embedding for input %d", i)
+		}
+	}
+
+	return vectors, nil
+}
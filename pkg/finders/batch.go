@@ -0,0 +1,51 @@
+package finders
+
+import (
+	"context"
+	"sync"
+	"transcribify/internal/models"
+)
+
+// Result of the single request of FindAll
+type Result struct {
+	Request models.VideoRequest
+	Video   *models.YTVideo
+	Err     error
+}
+
+// FindAll runs finder for every request with at most concurrency parallel calls.
+// Results are returned in the order of requests.
+func FindAll(ctx context.Context, finder Finder, requests []models.VideoRequest, concurrency int) []Result {
+	var (
+		results = make([]Result, len(requests))
+		slots   = make(chan struct{}, concurrency)
+		wg      sync.WaitGroup
+	)
+
+	if concurrency < 1 {
+		slots = make(chan struct{}, 1)
+	}
+
+	for i, request := range requests {
+		results[i].Request = request
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, request models.VideoRequest) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			results[i].Video, results[i].Err = finder.Find(ctx, request)
+		}(i, request)
+	}
+
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,59 @@
+package finders
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+	"transcribify/internal/models"
+)
+
+type countingFinder struct {
+	running int32
+	max     int32
+}
+
+func (c *countingFinder) Find(_ context.Context, request models.VideoRequest) (*models.YTVideo, error) {
+	n := atomic.AddInt32(&c.running, 1)
+	defer atomic.AddInt32(&c.running, -1)
+
+	for {
+		m := atomic.LoadInt32(&c.max)
+		if n <= m || atomic.CompareAndSwapInt32(&c.max, m, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if request.Language == "de" {
+		return nil, ErrNoCaptions
+	}
+
+	return &models.YTVideo{Title: request.VideoID}, nil
+}
+
+func TestFindAll(t *testing.T) {
+	finder := &countingFinder{}
+	requests := []models.VideoRequest{
+		{VideoID: "00000000001", Language: "en"},
+		{VideoID: "00000000002", Language: "de"},
+		{VideoID: "00000000003", Language: "en"},
+		{VideoID: "00000000004", Language: "en"},
+		{VideoID: "00000000005", Language: "en"},
+	}
+
+	results := FindAll(context.Background(), finder, requests, 2)
+
+	assert.Len(t, results, len(requests))
+	assert.LessOrEqual(t, finder.max, int32(2))
+	for i, result := range results {
+		assert.Equal(t, requests[i], result.Request)
+		if requests[i].Language == "de" {
+			assert.Error(t, result.Err)
+			continue
+		}
+		assert.NoError(t, result.Err)
+		assert.Equal(t, requests[i].VideoID, result.Video.Title)
+	}
+}
@@ -0,0 +1,126 @@
+package finders
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// BreakerState of the circuit breaker
+type BreakerState string
+
+const (
+	// BreakerClosed passes every request
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen fails fast until cooldown is over
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen passes a single probe request
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+var ErrCircuitOpen = errors.New("transcript provider is temporarily unavailable")
+
+// Breaker opens after threshold consecutive failures and fails fast for cooldown.
+// Then a single probe decides whether to close it or to open it again.
+// Breaker with threshold < 1 is always closed.
+type Breaker struct {
+	mu        sync.Mutex
+	state     BreakerState
+	failures  int
+	probing   bool
+	openedAt  time.Time
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+	// onChange is called under lock, it must not call Breaker methods
+	onChange func(from, to BreakerState)
+}
+
+func NewBreaker(threshold int, cooldown time.Duration, onChange func(from, to BreakerState)) *Breaker {
+	if onChange == nil {
+		onChange = func(BreakerState, BreakerState) {}
+	}
+
+	return &Breaker{
+		state:     BreakerClosed,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		onChange:  onChange,
+	}
+}
+
+// Allow returns UpstreamError of ErrCircuitOpen kind with time left to cooldown end if request must fail fast
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		left := b.cooldown - b.now().Sub(b.openedAt)
+		if left > 0 {
+			return &UpstreamError{Kind: ErrCircuitOpen, RetryAfter: left}
+		}
+		b.set(BreakerHalfOpen)
+		b.probing = true
+	case BreakerHalfOpen:
+		if b.probing {
+			return &UpstreamError{Kind: ErrCircuitOpen, RetryAfter: time.Second}
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+// Success closes the breaker
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	b.set(BreakerClosed)
+}
+
+// Failure opens the breaker after threshold consecutive failures or failed probe
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold < 1 {
+		return
+	}
+
+	b.failures++
+	b.probing = false
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		b.set(BreakerOpen)
+	}
+}
+
+// Cancel releases probe of cancelled request without changing the state
+func (b *Breaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+func (b *Breaker) set(state BreakerState) {
+	if b.state == state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+	b.onChange(from, state)
+}
@@ -0,0 +1,169 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"transcribify/internal/models"
+)
+
+var errLookupPanicked = errors.New("concurrent video lookup failed")
+
+// subscriberBuffer is the number of progress events kept for a slow subscriber, the next ones are dropped
+const subscriberBuffer = 32
+
+// call is a lookup of the video shared by concurrent requests
+type call struct {
+	done  chan struct{}
+	video *models.YTVideo
+	err   error
+	// cancelled is set if the caller context was done, so the result is unreliable for waiters
+	cancelled bool
+
+	mu sync.Mutex
+	// progress reported so far is replayed to joining waiters
+	progress    []Progress
+	subscribers map[int]chan Progress
+	next        int
+}
+
+// report sends progress of the lookup to the caller and waiters without blocking on them
+func (c *call) report(progress Progress) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.progress = append(c.progress, progress)
+	for _, events := range c.subscribers {
+		push(events, progress)
+	}
+}
+
+// subscribe replays reported progress to fn and sends it the next one until unsubscribe is called.
+// fn is called by its own goroutine, so a slow fn doesn`t block the lookup, but misses events
+// when its buffer is full. Unsubscribe waits until buffered events are sent to fn.
+func (c *call) subscribe(fn ProgressFunc) (unsubscribe func()) {
+	events := make(chan Progress, subscriberBuffer)
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for progress := range events {
+			fn(progress)
+		}
+	}()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, progress := range c.progress {
+		push(events, progress)
+	}
+
+	id := c.next
+	c.next++
+	c.subscribers[id] = events
+
+	return func() {
+		c.mu.Lock()
+		delete(c.subscribers, id)
+		close(events)
+		c.mu.Unlock()
+
+		<-drained
+	}
+}
+
+// push sends progress to events or drops it if events is full
+func push(events chan Progress, progress Progress) {
+	select {
+	case events <- progress:
+	default:
+	}
+}
+
+// flight coalesces concurrent lookups of the same video and language into a single call
+type flight struct {
+	mu    sync.Mutex
+	calls map[models.VideoRequest]*call
+}
+
+func newFlight() *flight {
+	return &flight{calls: make(map[models.VideoRequest]*call)}
+}
+
+// do calls fn once for concurrent requests with the same key. Waiters receive a copy of the caller video
+// or the same error. If the caller context was done, waiter repeats lookup with its own context.
+// Waiters report StatusShared and then receive Progress of the caller from the start.
+func (f *flight) do(
+	ctx context.Context,
+	key models.VideoRequest,
+	fn func(context.Context) (*models.YTVideo, error),
+) (*models.YTVideo, error) {
+	for {
+		f.mu.Lock()
+		c, ok := f.calls[key]
+		if !ok {
+			c = &call{done: make(chan struct{}), subscribers: make(map[int]chan Progress)}
+			f.calls[key] = c
+			f.mu.Unlock()
+
+			return f.run(ctx, key, c, fn)
+		}
+		f.mu.Unlock()
+
+		if err := wait(ctx, c); err != nil {
+			return nil, err
+		}
+
+		if c.cancelled && ctx.Err() == nil {
+			continue
+		}
+		if c.err != nil {
+			return nil, c.err
+		}
+
+		video := *c.video
+
+		return &video, nil
+	}
+}
+
+// wait blocks until call is done, progress isn`t sent to ctx after return
+func wait(ctx context.Context, c *call) error {
+	if fn, ok := progressFunc(ctx); ok {
+		fn(Progress{Stage: StageProvider, Status: StatusShared})
+		defer c.subscribe(fn)()
+	}
+
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (f *flight) run(
+	ctx context.Context,
+	key models.VideoRequest,
+	c *call,
+	fn func(context.Context) (*models.YTVideo, error),
+) (*models.YTVideo, error) {
+	if caller, ok := progressFunc(ctx); ok {
+		// deferred first, so waiters are released before progress is sent to the caller
+		defer c.subscribe(caller)()
+	}
+
+	defer func() {
+		f.mu.Lock()
+		delete(f.calls, key)
+		f.mu.Unlock()
+		close(c.done)
+	}()
+
+	// kept if fn panics
+	c.err = errLookupPanicked
+	c.video, c.err = fn(WithProgress(ctx, c.report))
+	c.cancelled = c.err != nil && ctx.Err() != nil
+
+	return c.video, c.err
+}
@@ -0,0 +1,269 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"transcribify/internal/models"
+)
+
+// blockingProvider waits for release before returning the result
+type blockingProvider struct {
+	release chan struct{}
+	video   *models.YTVideo
+	err     error
+	calls   int32
+}
+
+func (b *blockingProvider) Name() string {
+	return "blocking"
+}
+
+func (b *blockingProvider) Fetch(ctx context.Context, _ models.VideoRequest) (*models.YTVideo, error) {
+	atomic.AddInt32(&b.calls, 1)
+
+	select {
+	case <-b.release:
+		return b.video, b.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// countingVideos counts inserts of memoryVideos
+type countingVideos struct {
+	*memoryVideos
+	inserts int32
+}
+
+func (c *countingVideos) CreateVideo(ctx context.Context, request models.VideoRequest, video *models.YTVideo) (int, error) {
+	atomic.AddInt32(&c.inserts, 1)
+
+	return c.memoryVideos.CreateVideo(ctx, request, video)
+}
+
+// waitShared waits until n requests join the lookup in progress
+func waitShared(t *testing.T, shared *int32, n int32) {
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(shared) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d waiting requests, got %d", n, atomic.LoadInt32(shared))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func withSharedCounter(ctx context.Context, shared *int32) context.Context {
+	return WithProgress(ctx, func(p Progress) {
+		if p.Status == StatusShared {
+			atomic.AddInt32(shared, 1)
+		}
+	})
+}
+
+func TestAPIFinder_Find_Coalesces(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		inserts int32
+	}{
+		{
+			name:    "Shared video",
+			inserts: 1,
+		},
+		{
+			name: "Shared error",
+			err:  ErrNoCaptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				repo     = &countingVideos{memoryVideos: &memoryVideos{videos: make(map[models.VideoRequest]*models.YTVideo)}}
+				provider = &blockingProvider{release: make(chan struct{}), video: &models.YTVideo{Title: "Title"}, err: tt.err}
+				finder   = NewAPIFinder(NewRegistry(provider), repo)
+				request  = models.VideoRequest{VideoID: "00000000000", Language: "en"}
+				shared   int32
+				wg       sync.WaitGroup
+			)
+			if tt.err != nil {
+				provider.video = nil
+			}
+
+			const requests = 20
+			videos := make([]*models.YTVideo, requests)
+			errs := make([]error, requests)
+			ctx := withSharedCounter(context.Background(), &shared)
+
+			for i := 0; i < requests; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					videos[i], errs[i] = finder.Find(ctx, request)
+				}(i)
+			}
+
+			waitShared(t, &shared, requests-1)
+			close(provider.release)
+			wg.Wait()
+
+			assert.Equal(t, int32(1), atomic.LoadInt32(&provider.calls))
+			assert.Equal(t, tt.inserts, atomic.LoadInt32(&repo.inserts))
+
+			for i := 0; i < requests; i++ {
+				if tt.err != nil {
+					assert.ErrorIs(t, errs[i], tt.err)
+					continue
+				}
+				if assert.NoError(t, errs[i]) {
+					assert.Equal(t, "Title", videos[i].Title)
+					assert.Equal(t, 1, videos[i].Id)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIFinder_Find_CancelledCaller(t *testing.T) {
+	var (
+		repo     = &memoryVideos{videos: make(map[models.VideoRequest]*models.YTVideo)}
+		provider = &blockingProvider{release: make(chan struct{}), video: &models.YTVideo{Title: "Title"}}
+		finder   = NewAPIFinder(NewRegistry(provider), repo)
+		request  = models.VideoRequest{VideoID: "00000000000", Language: "en"}
+		shared   int32
+	)
+
+	callerCtx, cancel := context.WithCancel(context.Background())
+	callerErr := make(chan error)
+	go func() {
+		_, err := finder.Find(callerCtx, request)
+		callerErr <- err
+	}()
+
+	for atomic.LoadInt32(&provider.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	waiter := make(chan error)
+	go func() {
+		_, err := finder.Find(withSharedCounter(context.Background(), &shared), request)
+		waiter <- err
+	}()
+
+	waitShared(t, &shared, 1)
+	cancel()
+	assert.True(t, errors.Is(<-callerErr, context.Canceled))
+
+	for atomic.LoadInt32(&provider.calls) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	close(provider.release)
+
+	assert.NoError(t, <-waiter, "waiter repeats lookup if caller is cancelled")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&provider.calls))
+}
+
+func TestAPIFinder_Find_SharedProgress(t *testing.T) {
+	var (
+		repo     = &memoryVideos{videos: make(map[models.VideoRequest]*models.YTVideo)}
+		provider = &blockingProvider{release: make(chan struct{}), video: &models.YTVideo{Title: "Title"}}
+		finder   = NewAPIFinder(NewRegistry(provider), repo)
+		request  = models.VideoRequest{VideoID: "00000000000", Language: "en"}
+		mu       sync.Mutex
+		stages   []Progress
+		shared   int32
+	)
+
+	caller := make(chan error)
+	go func() {
+		_, err := finder.Find(context.Background(), request)
+		caller <- err
+	}()
+
+	for atomic.LoadInt32(&provider.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx := WithProgress(context.Background(), func(p Progress) {
+		if p.Status == StatusShared {
+			atomic.AddInt32(&shared, 1)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		stages = append(stages, p)
+	})
+
+	waiter := make(chan error)
+	go func() {
+		_, err := finder.Find(ctx, request)
+		waiter <- err
+	}()
+
+	waitShared(t, &shared, 1)
+	close(provider.release)
+
+	assert.NoError(t, <-caller)
+	assert.NoError(t, <-waiter)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []Progress{
+		{Stage: StageProvider, Status: StatusShared},
+		{Stage: StageCache, Status: StatusStarted},
+		{Stage: StageCache, Status: StatusMiss},
+		{Stage: StageProvider, Status: StatusStarted, Provider: "blocking"},
+		{Stage: StageProvider, Status: StatusDone, Provider: "blocking"},
+		{Stage: StagePersist, Status: StatusStarted},
+		{Stage: StagePersist, Status: StatusDone},
+	}, stages)
+}
+
+func TestAPIFinder_Find_StalledSubscriber(t *testing.T) {
+	var (
+		repo     = &memoryVideos{videos: make(map[models.VideoRequest]*models.YTVideo)}
+		provider = &blockingProvider{release: make(chan struct{}), video: &models.YTVideo{Title: "Title"}}
+		finder   = NewAPIFinder(NewRegistry(provider), repo)
+		request  = models.VideoRequest{VideoID: "00000000000", Language: "en"}
+		stalled  = make(chan struct{})
+		shared   int32
+	)
+	defer close(stalled)
+
+	caller := make(chan error)
+	go func() {
+		_, err := finder.Find(context.Background(), request)
+		caller <- err
+	}()
+
+	for atomic.LoadInt32(&provider.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// subscriber never returns, like a client which doesn`t read the stream
+	ctx := WithProgress(context.Background(), func(p Progress) {
+		if p.Status == StatusShared {
+			atomic.AddInt32(&shared, 1)
+			return
+		}
+		<-stalled
+	})
+	go func() {
+		_, _ = finder.Find(ctx, request)
+	}()
+
+	waitShared(t, &shared, 1)
+	close(provider.release)
+
+	select {
+	case err := <-caller:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("lookup is blocked by stalled subscriber")
+	}
+}
@@ -0,0 +1,212 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorCode is a machine-readable kind of failed video lookup
+type ErrorCode string
+
+const (
+	CodeNotFound      ErrorCode = "video_not_found"
+	CodeNoCaptions    ErrorCode = "no_captions"
+	CodeQuotaExceeded ErrorCode = "quota_exceeded"
+	CodeUnauthorized  ErrorCode = "upstream_unauthorized"
+	CodeMalformed     ErrorCode = "malformed_response"
+	CodeTimeout       ErrorCode = "upstream_timeout"
+	CodeUpstream      ErrorCode = "upstream_error"
+	CodeUnavailable   ErrorCode = "upstream_unavailable"
+	CodeInternal      ErrorCode = "internal_error"
+)
+
+// maxErrorBody is the longest upstream body kept in UpstreamError
+const maxErrorBody = 2048
+
+var (
+	ErrNotFound      = errors.New("video not found")
+	ErrNoCaptions    = errors.New("video has no captions in the language")
+	ErrQuotaExceeded = errors.New("upstream quota exceeded")
+	ErrUnauthorized  = errors.New("upstream authorization failed")
+	ErrMalformed     = errors.New("malformed upstream response")
+	ErrTimeout       = errors.New("upstream timeout")
+	ErrUpstream      = errors.New("upstream error")
+)
+
+// UpstreamError is a failed response of transcript provider API.
+// errors.Is matches its Kind, Body is kept for logs and isn`t a part of the message.
+type UpstreamError struct {
+	// Kind is one of sentinel errors
+	Kind   error
+	Status int
+	Body   string
+	// RetryAfter is set for ErrQuotaExceeded if upstream sent Retry-After header
+	RetryAfter time.Duration
+	Err        error
+}
+
+// newStatusError classifies non 2xx response status. Status of ErrUpstream tells
+// server failures (5xx) from client errors (4xx).
+func newStatusError(response *http.Response, body []byte) *UpstreamError {
+	upstream := &UpstreamError{Kind: ErrUpstream, Status: response.StatusCode, Body: truncate(body)}
+
+	switch response.StatusCode {
+	case http.StatusNotFound:
+		upstream.Kind = ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		upstream.Kind = ErrUnauthorized
+	case http.StatusTooManyRequests:
+		upstream.Kind = ErrQuotaExceeded
+		upstream.RetryAfter = parseRetryAfter(response.Header.Get("Retry-After"), time.Now())
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		upstream.Kind = ErrTimeout
+	}
+
+	return upstream
+}
+
+// newMalformedError keeps body of response which can`t be decoded
+func newMalformedError(status int, body []byte, err error) *UpstreamError {
+	return &UpstreamError{Kind: ErrMalformed, Status: status, Body: truncate(body), Err: err}
+}
+
+// wrapTransportError marks timeouts and connection failures of http.Client. Cancellation isn`t wrapped.
+func wrapTransportError(err error) error {
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return err
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return &UpstreamError{Kind: ErrTimeout, Err: err}
+	default:
+		return &UpstreamError{Kind: ErrUpstream, Err: err}
+	}
+}
+
+func (e *UpstreamError) Error() string {
+	msg := e.Kind.Error()
+	if e.Status != 0 {
+		msg += fmt.Sprintf(" (status %d)", e.Status)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+func (e *UpstreamError) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *UpstreamError) Unwrap() error {
+	return e.Err
+}
+
+// Classify returns error code and HTTP status of the failed video lookup
+func Classify(err error) (ErrorCode, int) {
+	switch {
+	case err == nil:
+		return "", http.StatusOK
+	case errors.Is(err, ErrNotFound):
+		return CodeNotFound, http.StatusNotFound
+	case errors.Is(err, ErrNoCaptions), errors.Is(err, ErrLanguageUnavailable):
+		return CodeNoCaptions, http.StatusNotFound
+	case errors.Is(err, ErrQuotaExceeded):
+		return CodeQuotaExceeded, http.StatusServiceUnavailable
+	case errors.Is(err, ErrCircuitOpen):
+		return CodeUnavailable, http.StatusServiceUnavailable
+	case errors.Is(err, ErrUnauthorized):
+		return CodeUnauthorized, http.StatusBadGateway
+	case errors.Is(err, ErrMalformed):
+		return CodeMalformed, http.StatusBadGateway
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout, http.StatusGatewayTimeout
+	case errors.Is(err, ErrUpstream):
+		return CodeUpstream, http.StatusBadGateway
+	default:
+		return CodeInternal, http.StatusInternalServerError
+	}
+}
+
+// codeErrors describe error codes to the client
+var codeErrors = map[ErrorCode]error{
+	CodeNotFound:      ErrNotFound,
+	CodeNoCaptions:    ErrNoCaptions,
+	CodeQuotaExceeded: ErrQuotaExceeded,
+	CodeUnavailable:   ErrCircuitOpen,
+	CodeUnauthorized:  ErrUnauthorized,
+	CodeMalformed:     ErrMalformed,
+	CodeTimeout:       ErrTimeout,
+	CodeUpstream:      ErrUpstream,
+}
+
+// Describe returns error code of the failed video lookup and its message for the client.
+// Upstream status, body and transport errors are left for logs.
+func Describe(err error) (ErrorCode, string) {
+	code, _ := Classify(err)
+	if kind, ok := codeErrors[code]; ok {
+		return code, kind.Error()
+	}
+
+	return code, "failed to find video"
+}
+
+// severity ranks failures of transcript providers, missing captions are the least severe
+func severity(err error) int {
+	code, _ := Classify(err)
+
+	switch code {
+	case CodeQuotaExceeded:
+		return 8
+	case CodeUnavailable:
+		return 7
+	case CodeUnauthorized:
+		return 6
+	case CodeTimeout:
+		return 5
+	case CodeUpstream:
+		return 4
+	case CodeMalformed:
+		return 3
+	case CodeInternal:
+		return 2
+	case CodeNotFound:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// parseRetryAfter accepts seconds and HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+func truncate(body []byte) string {
+	if len(body) > maxErrorBody {
+		return string(body[:maxErrorBody]) + "…"
+	}
+
+	return string(body)
+}
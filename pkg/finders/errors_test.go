@@ -0,0 +1,225 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"transcribify/internal/models"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func stubResponse(status int, contentType, body string, header http.Header) roundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Set("Content-Type", contentType)
+
+		return &http.Response{
+			StatusCode: status,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}
+}
+
+func TestRapidAPIProvider_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		transport  http.RoundTripper
+		code       ErrorCode
+		status     int
+		retryAfter time.Duration
+	}{
+		{
+			name:      "Not found",
+			transport: stubResponse(http.StatusNotFound, "application/json", `{"message":"not found"}`, nil),
+			code:      CodeNotFound,
+			status:    http.StatusNotFound,
+		},
+		{
+			name:      "Empty array",
+			transport: stubResponse(http.StatusOK, "application/json", `[]`, nil),
+			code:      CodeNoCaptions,
+			status:    http.StatusNotFound,
+		},
+		{
+			name:      "Error object",
+			transport: stubResponse(http.StatusOK, "application/json", `{"error":"This video has no subtitles in de"}`, nil),
+			code:      CodeNoCaptions,
+			status:    http.StatusNotFound,
+		},
+		{
+			name:       "Quota exceeded",
+			transport:  stubResponse(http.StatusTooManyRequests, "application/json", `{"message":"You have exceeded the rate limit"}`, http.Header{"Retry-After": {"30"}}),
+			code:       CodeQuotaExceeded,
+			status:     http.StatusServiceUnavailable,
+			retryAfter: 30 * time.Second,
+		},
+		{
+			name:      "Invalid key",
+			transport: stubResponse(http.StatusForbidden, "application/json", `{"message":"You are not subscribed to this API."}`, nil),
+			code:      CodeUnauthorized,
+			status:    http.StatusBadGateway,
+		},
+		{
+			name:      "HTML error page",
+			transport: stubResponse(http.StatusOK, "text/html", `<html>oops</html>`, nil),
+			code:      CodeMalformed,
+			status:    http.StatusBadGateway,
+		},
+		{
+			name:      "Malformed json",
+			transport: stubResponse(http.StatusOK, "application/json", `[{"title":`, nil),
+			code:      CodeMalformed,
+			status:    http.StatusBadGateway,
+		},
+		{
+			name:      "Server error",
+			transport: stubResponse(http.StatusInternalServerError, "text/plain", `boom`, nil),
+			code:      CodeUpstream,
+			status:    http.StatusBadGateway,
+		},
+		{
+			name: "Timeout",
+			transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+				return nil, context.DeadlineExceeded
+			}),
+			code:   CodeTimeout,
+			status: http.StatusGatewayTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewRapidAPIProviderWithHeaders(&http.Client{Transport: tt.transport}, http.Header{})
+
+			_, err := provider.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000001", Language: "de"})
+
+			code, status := Classify(err)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.status, status)
+
+			var upstream *UpstreamError
+			if assert.True(t, errors.As(err, &upstream)) {
+				assert.Equal(t, tt.retryAfter, upstream.RetryAfter)
+			}
+		})
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		transport := stubResponse(http.StatusOK, "application/json; charset=utf-8", `[{"title":"found"}]`, nil)
+		provider := NewRapidAPIProviderWithHeaders(&http.Client{Transport: transport}, http.Header{})
+
+		video, err := provider.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000001", Language: "en"})
+		assert.NoError(t, err)
+		assert.Equal(t, "found", video.Title)
+	})
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code ErrorCode
+	}{
+		{name: "Chain uses the most severe error", err: &ChainError{Errors: []*ProviderError{
+			{Provider: RapidAPI, Err: &UpstreamError{Kind: ErrQuotaExceeded}},
+			{Provider: TimedText, Err: fmt.Errorf("%w: %q", ErrNoCaptions, "de")},
+		}}, code: CodeQuotaExceeded},
+		{name: "Chain of missing captions", err: &ChainError{Errors: []*ProviderError{
+			{Provider: RapidAPI, Err: &UpstreamError{Kind: ErrNotFound}},
+			{Provider: Local, Err: fmt.Errorf("%w: %q", ErrNoCaptions, "de")},
+		}}, code: CodeNotFound},
+		{name: "Unavailable language", err: fmt.Errorf("%w: %q", ErrLanguageUnavailable, "de"), code: CodeNoCaptions},
+		{name: "Unplayable", err: ErrUnplayable, code: CodeNotFound},
+		{name: "Repository", err: errors.New("conn closed"), code: CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := Classify(tt.err)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    ErrorCode
+		message string
+	}{
+		{
+			name:    "Upstream details are left out",
+			err:     &UpstreamError{Kind: ErrUpstream, Status: 502, Body: "<html>", Err: errors.New("dial tcp 10.0.0.1:443")},
+			code:    CodeUpstream,
+			message: "upstream error",
+		},
+		{
+			name:    "Wrapped timeout",
+			err:     fmt.Errorf("rapidapi: %w", &UpstreamError{Kind: ErrTimeout, Err: errors.New("i/o timeout")}),
+			code:    CodeTimeout,
+			message: "upstream timeout",
+		},
+		{
+			name:    "Repository",
+			err:     errors.New("conn closed"),
+			code:    CodeInternal,
+			message: "failed to find video",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, message := Describe(tt.err)
+
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.message, message)
+		})
+	}
+}
+
+func TestRegistry_Fetch_Classify(t *testing.T) {
+	dir := t.TempDir()
+	transport := stubResponse(http.StatusTooManyRequests, "application/json", `{"message":"Too many requests"}`,
+		http.Header{"Retry-After": []string{"30"}})
+
+	registry := NewRegistry(
+		NewRapidAPIProviderWithHeaders(&http.Client{Transport: transport}, http.Header{}),
+		NewLocalProvider(dir),
+	)
+
+	_, _, err := registry.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000001", Language: "en"})
+
+	code, status := Classify(err)
+	assert.Equal(t, CodeQuotaExceeded, code)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	var upstream *UpstreamError
+	if assert.ErrorAs(t, err, &upstream) {
+		assert.Equal(t, 30*time.Second, upstream.RetryAfter)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+}
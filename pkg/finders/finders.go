@@ -2,10 +2,7 @@ package finders
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"os"
 	"transcribify/internal/models"
 	"transcribify/pkg/repository"
 )
@@ -16,75 +13,62 @@ type (
 	Finder interface {
 		Find(context.Context, models.VideoRequest) (*models.YTVideo, error)
 	}
+
+	// APIFinder looks for video in repository at first,
+	// then walks Registry providers and stores the result.
+	// Concurrent lookups of the same video and language share a single call.
 	APIFinder struct {
-		client  *http.Client
-		headers http.Header
-		repo    repository.Video
+		registry *Registry
+		repo     repository.Video
+		inflight *flight
 	}
 )
 
-func NewAPIFinder(client *http.Client, repository repository.Video) *APIFinder {
-	return newAPIFinderWithDefaultHeaders(client, repository)
+func NewAPIFinder(registry *Registry, repository repository.Video) *APIFinder {
+	return &APIFinder{
+		registry: registry,
+		repo:     repository,
+		inflight: newFlight(),
+	}
 }
 
-func newAPIFinderWithDefaultHeaders(client *http.Client, repository repository.Video) *APIFinder {
-	headers := http.Header{}
-
-	headers.Add("X-RapidAPI-Key", os.Getenv("VIDEO_API_KEY"))
-	headers.Add("X-RapidAPI-Host", "youtube-transcriptor.p.rapidapi.com")
-
-	return NewAPIFinderWithHeaders(
-		client,
-		headers,
+// NewAPIFinderWithHeaders uses only RapidAPIProvider with specified headers
+func NewAPIFinderWithHeaders(client *http.Client, headers http.Header, repository repository.Video) *APIFinder {
+	return NewAPIFinder(
+		NewRegistry(NewRapidAPIProviderWithHeaders(client, headers)),
 		repository,
 	)
 }
 
-func NewAPIFinderWithHeaders(client *http.Client, headers http.Header, repository repository.Video) *APIFinder {
-	return &APIFinder{
-		client:  client,
-		headers: headers,
-		repo:    repository,
-	}
-}
-
 func (a *APIFinder) Find(ctx context.Context, video models.VideoRequest) (*models.YTVideo, error) {
-	var (
-		APIURL = fmt.Sprintf(
-			"https://youtube-transcriptor.p.rapidapi.com/transcript?video_id=%s&lang=%s",
-			video.VideoID,
-			video.Language,
-		)
-		data []models.YTVideo
-	)
+	return a.inflight.do(ctx, video, func(ctx context.Context) (*models.YTVideo, error) {
+		return a.find(ctx, video)
+	})
+}
 
+func (a *APIFinder) find(ctx context.Context, video models.VideoRequest) (*models.YTVideo, error) {
 	// Find in repository
+	Report(ctx, Progress{Stage: StageCache, Status: StatusStarted})
 	read, err := a.repo.GetVideoByIDLang(ctx, video)
 	if err == nil {
+		Report(ctx, Progress{Stage: StageCache, Status: StatusHit})
 		return read, err
 	}
+	Report(ctx, Progress{Stage: StageCache, Status: StatusMiss})
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, APIURL, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header = a.headers
-
-	response, err := a.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.NewDecoder(response.Body).Decode(&data)
+	data, provider, err := a.registry.Fetch(ctx, video)
 	if err != nil {
 		return nil, err
 	}
+	data.Provider = provider
+	data.Fetched = true
 
-	_, err = a.repo.CreateVideo(ctx, video, &data[0])
+	Report(ctx, Progress{Stage: StagePersist, Status: StatusStarted})
+	data.Id, err = a.repo.CreateVideo(ctx, video, data)
+	reportErr(ctx, Progress{Stage: StagePersist}, err)
 	if err != nil {
 		return nil, err
 	}
 
-	return &data[0], nil
+	return data, nil
 }
@@ -0,0 +1,82 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"transcribify/internal/models"
+	"transcribify/pkg/repository"
+)
+
+type memoryVideos struct {
+	repository.Video
+
+	mu     sync.Mutex
+	videos map[models.VideoRequest]*models.YTVideo
+}
+
+func (m *memoryVideos) GetVideoByIDLang(_ context.Context, request models.VideoRequest) (*models.YTVideo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// repository reads a new video every time
+	if video, ok := m.videos[request]; ok {
+		read := *video
+		read.Fetched = false
+
+		return &read, nil
+	}
+
+	return nil, errors.New("no rows in result set")
+}
+
+func (m *memoryVideos) CreateVideo(_ context.Context, request models.VideoRequest, video *models.YTVideo) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.videos[request] = video
+
+	return len(m.videos), nil
+}
+
+func TestAPIFinder_Find(t *testing.T) {
+	var (
+		repo     = &memoryVideos{videos: make(map[models.VideoRequest]*models.YTVideo)}
+		provider = &stubProvider{name: "stub", video: &models.YTVideo{Title: "Title"}}
+		finder   = NewAPIFinder(NewRegistry(provider), repo)
+		request  = models.VideoRequest{VideoID: "00000000000", Language: "en"}
+		stages   []Progress
+	)
+
+	ctx := WithProgress(context.Background(), func(p Progress) {
+		stages = append(stages, p)
+	})
+
+	video, err := finder.Find(ctx, request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, video.Id)
+	assert.Equal(t, "stub", video.Provider)
+	assert.True(t, video.Fetched)
+	assert.Equal(t, []Progress{
+		{Stage: StageCache, Status: StatusStarted},
+		{Stage: StageCache, Status: StatusMiss},
+		{Stage: StageProvider, Status: StatusStarted, Provider: "stub"},
+		{Stage: StageProvider, Status: StatusDone, Provider: "stub"},
+		{Stage: StagePersist, Status: StatusStarted},
+		{Stage: StagePersist, Status: StatusDone},
+	}, stages)
+
+	stages = nil
+	video, err = finder.Find(ctx, request)
+
+	assert.NoError(t, err)
+	assert.False(t, video.Fetched)
+	assert.Equal(t, 1, provider.calls)
+	assert.Equal(t, []Progress{
+		{Stage: StageCache, Status: StatusStarted},
+		{Stage: StageCache, Status: StatusHit},
+	}, stages)
+}
@@ -0,0 +1,66 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"transcribify/internal/models"
+)
+
+// AllLanguages requests every available transcription language
+const AllLanguages = "all"
+
+var ErrNoLanguages = errors.New("no languages to discover available transcriptions")
+
+// ParseLanguages splits comma separated languages. Returns all=true for AllLanguages.
+// Languages are deduplicated in the order of appearance.
+func ParseLanguages(lang string) (languages []string, all bool) {
+	seen := make(map[string]bool)
+
+	for _, l := range strings.Split(lang, ",") {
+		l = strings.TrimSpace(l)
+		if strings.EqualFold(l, AllLanguages) {
+			all = true
+			continue
+		}
+		if l == "" || seen[l] {
+			continue
+		}
+		seen[l] = true
+		languages = append(languages, l)
+	}
+
+	return languages, all
+}
+
+// Discover finds video in the first available of seeds languages to learn models.YTVideo AvailableLangs.
+// The next seed is tried only if captions are missing, so unknown video or upstream failure costs one request.
+// Returns the found video with its language or error of the last tried seed.
+func Discover(ctx context.Context, finder Finder, videoID string, seeds []string) (*models.YTVideo, string, error) {
+	err := ErrNoLanguages
+
+	for _, lang := range seeds {
+		var video *models.YTVideo
+
+		video, err = finder.Find(ctx, models.VideoRequest{VideoID: videoID, Language: lang})
+		if err == nil {
+			return video, lang, nil
+		}
+		if ctx.Err() != nil || !isUnavailable(err) {
+			break
+		}
+	}
+
+	return nil, "", err
+}
+
+// FindLanguages runs finder for every language of the video with at most concurrency parallel calls.
+// Results are returned in the order of languages.
+func FindLanguages(ctx context.Context, finder Finder, videoID string, languages []string, concurrency int) []Result {
+	requests := make([]models.VideoRequest, len(languages))
+	for i, lang := range languages {
+		requests[i] = models.VideoRequest{VideoID: videoID, Language: lang}
+	}
+
+	return FindAll(ctx, finder, requests, concurrency)
+}
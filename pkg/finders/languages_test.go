@@ -0,0 +1,58 @@
+package finders
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseLanguages(t *testing.T) {
+	tests := []struct {
+		input     string
+		languages []string
+		all       bool
+	}{
+		{input: "en", languages: []string{"en"}},
+		{input: " en, de ,en,", languages: []string{"en", "de"}},
+		{input: "all", all: true},
+		{input: "ALL,fr", languages: []string{"fr"}, all: true},
+	}
+
+	for _, test := range tests {
+		languages, all := ParseLanguages(test.input)
+		assert.Equal(t, test.languages, languages, test.input)
+		assert.Equal(t, test.all, all, test.input)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	finder := &countingFinder{}
+
+	video, lang, err := Discover(context.Background(), finder, "00000000001", []string{"de", "en"})
+	assert.NoError(t, err)
+	assert.Equal(t, "en", lang)
+	assert.Equal(t, "00000000001", video.Title)
+
+	_, _, err = Discover(context.Background(), finder, "00000000001", []string{"de"})
+	assert.ErrorIs(t, err, ErrNoCaptions)
+
+	failing := &failingFinder{err: ErrNotFound}
+	_, _, err = Discover(context.Background(), failing, "00000000001", []string{"de", "en"})
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 1, failing.calls, "unknown video isn`t tried in other languages")
+
+	_, _, err = Discover(context.Background(), finder, "00000000001", nil)
+	assert.ErrorIs(t, err, ErrNoLanguages)
+}
+
+func TestFindLanguages(t *testing.T) {
+	finder := &countingFinder{}
+
+	results := FindLanguages(context.Background(), finder, "00000000001", []string{"en", "de", "fr", "es"}, 2)
+
+	assert.Len(t, results, 4)
+	assert.LessOrEqual(t, finder.max, int32(2))
+	assert.Equal(t, "de", results[1].Request.Language)
+	assert.Error(t, results[1].Err)
+	assert.NoError(t, results[3].Err)
+}
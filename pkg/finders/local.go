@@ -0,0 +1,90 @@
+package finders
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"transcribify/internal/models"
+	"transcribify/pkg/subtitles"
+)
+
+var ErrNoSubtitlesDir = errors.New("subtitles directory is not configured")
+
+// LocalProvider reads transcriptions from directory with `{video id}.{language}.json` files.
+// Files contain models.YTVideo encoded in json. Subtitle files `{video id}.{language}.srt`
+// (or .vtt, .sbv, .xml) are read if there is no json file.
+type LocalProvider struct {
+	dir string
+}
+
+// localExtensions in the order of lookup
+var localExtensions = []string{"json", "srt", "vtt", "sbv", "xml"}
+
+func NewLocalProvider(dir string) *LocalProvider {
+	return &LocalProvider{dir: dir}
+}
+
+func (p *LocalProvider) Name() string {
+	return Local
+}
+
+func (p *LocalProvider) Fetch(ctx context.Context, request models.VideoRequest) (*models.YTVideo, error) {
+	if p.dir == "" {
+		return nil, ErrNoSubtitlesDir
+	}
+
+	var (
+		data []byte
+		ext  string
+		err  error
+	)
+
+	for _, ext = range localExtensions {
+		// VideoRequest is validated, but base is used to not leave the directory
+		name := filepath.Base(fmt.Sprintf("%s.%s.%s", request.VideoID, request.Language, ext))
+
+		data, err = os.ReadFile(filepath.Join(p.dir, name))
+		if !errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %v", ErrNoCaptions, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	Report(ctx, Progress{Stage: StageParse, Status: StatusStarted, Provider: Local})
+	video, err := parseLocal(ext, data)
+	reportErr(ctx, Progress{Stage: StageParse, Provider: Local}, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
+
+func parseLocal(ext string, data []byte) (*models.YTVideo, error) {
+	video := new(models.YTVideo)
+
+	if ext == "json" {
+		return video, json.Unmarshal(data, video)
+	}
+
+	format, err := subtitles.ParseFormat(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	video.Transcription, err = subtitles.Parse(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
@@ -0,0 +1,108 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"transcribify/internal/models"
+	"transcribify/pkg/language"
+)
+
+var ErrLanguageUnavailable = errors.New("language is not available")
+
+type (
+	// Translator is a fallback when none of preferred languages is available.
+	// It returns video translated from the source language to the requested one.
+	Translator interface {
+		TranslateVideo(ctx context.Context, source *models.YTVideo, from string, to models.VideoRequest) (*models.YTVideo, error)
+	}
+
+	// Served describes which language was served instead of the requested one and why
+	Served struct {
+		Requested string
+		Language  string
+		Reason    language.Reason
+		// Source is the language translation was made from
+		Source string
+	}
+
+	// Negotiator finds video in the requested language, otherwise in the best match of
+	// the preferences and AvailableLangs, otherwise translates it if translator is set.
+	Negotiator struct {
+		finder     Finder
+		translator Translator
+	}
+)
+
+// NewNegotiator accepts nil translator
+func NewNegotiator(finder Finder, translator Translator) *Negotiator {
+	return &Negotiator{finder: finder, translator: translator}
+}
+
+// Find tries requested language at first. Preferences are the next languages in priority order,
+// translate allows falling through to translator.
+func (n *Negotiator) Find(ctx context.Context, request models.VideoRequest, preferences []string, translate bool) (*models.YTVideo, Served, error) {
+	served := Served{Requested: request.Language, Language: request.Language, Reason: language.Requested}
+
+	video, err := n.finder.Find(ctx, request)
+	if err == nil || ctx.Err() != nil || !isUnavailable(err) {
+		return video, served, err
+	}
+	requestErr := err
+
+	// learn available languages from any other language of the video
+	seeds := make([]string, 0, len(preferences)+1)
+	for _, p := range append(preferences[:len(preferences):len(preferences)], "en") {
+		if !language.Equal(p, request.Language) {
+			seeds = append(seeds, p)
+		}
+	}
+
+	source, sourceLang, err := Discover(ctx, n.finder, request.VideoID, seeds)
+	if err != nil {
+		return nil, served, requestErr
+	}
+
+	available := make([]string, 0, len(source.AvailableLangs))
+	for _, a := range source.AvailableLangs {
+		if !language.Equal(a, request.Language) {
+			available = append(available, a)
+		}
+	}
+
+	priority := append([]string{request.Language}, preferences...)
+	if match, reason, ok := language.Match(priority, available); ok {
+		served.Language, served.Reason = match, reason
+
+		if language.Equal(match, sourceLang) {
+			return source, served, nil
+		}
+
+		video, err = n.finder.Find(ctx, models.VideoRequest{VideoID: request.VideoID, Language: match})
+		if err == nil {
+			return video, served, nil
+		}
+		if !isUnavailable(err) {
+			return nil, served, err
+		}
+	}
+
+	if translate && n.translator != nil {
+		video, err = n.translator.TranslateVideo(ctx, source, sourceLang, request)
+		if err != nil {
+			return nil, served, err
+		}
+
+		served.Language, served.Reason, served.Source = request.Language, language.Translated, sourceLang
+
+		return video, served, nil
+	}
+
+	return nil, served, fmt.Errorf("%w: %q, available: %v: %v", ErrLanguageUnavailable, request.Language, source.AvailableLangs, requestErr)
+}
+
+// isUnavailable reports whether language is missing, other failures like unknown video, quota or timeout
+// are returned as is instead of multiplying requests with fallback
+func isUnavailable(err error) bool {
+	return errors.Is(err, ErrNoCaptions)
+}
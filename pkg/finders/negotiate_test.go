@@ -0,0 +1,150 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"transcribify/internal/models"
+	"transcribify/pkg/language"
+)
+
+// tracksFinder has captions only in the given languages
+type tracksFinder []string
+
+func (f tracksFinder) Find(_ context.Context, request models.VideoRequest) (*models.YTVideo, error) {
+	for _, lang := range f {
+		if lang == request.Language {
+			return &models.YTVideo{Title: lang, AvailableLangs: f}, nil
+		}
+	}
+
+	return nil, ErrNoCaptions
+}
+
+// failingFinder fails every call with err
+type failingFinder struct {
+	err   error
+	calls int
+}
+
+func (f *failingFinder) Find(context.Context, models.VideoRequest) (*models.YTVideo, error) {
+	f.calls++
+
+	return nil, f.err
+}
+
+type stubTranslator struct{}
+
+func (stubTranslator) TranslateVideo(_ context.Context, source *models.YTVideo, from string, to models.VideoRequest) (*models.YTVideo, error) {
+	return &models.YTVideo{Title: from + ">" + to.Language, AvailableLangs: source.AvailableLangs}, nil
+}
+
+func TestNegotiatorFind(t *testing.T) {
+	tests := []struct {
+		name        string
+		tracks      tracksFinder
+		translator  Translator
+		language    string
+		preferences []string
+		translate   bool
+		want        string
+		served      Served
+		err         error
+	}{
+		{
+			name:     "requested",
+			tracks:   tracksFinder{"en", "de"},
+			language: "de",
+			want:     "de",
+			served:   Served{Requested: "de", Language: "de", Reason: language.Requested},
+		},
+		{
+			name:        "regional variant",
+			tracks:      tracksFinder{"en-GB", "de"},
+			language:    "en",
+			preferences: []string{"de"},
+			want:        "en-GB",
+			served:      Served{Requested: "en", Language: "en-GB", Reason: language.Regional},
+		},
+		{
+			name:        "preference",
+			tracks:      tracksFinder{"en", "fr", "de"},
+			language:    "uk",
+			preferences: []string{"ru", "de", "fr"},
+			want:        "de",
+			served:      Served{Requested: "uk", Language: "de", Reason: language.Preference},
+		},
+		{
+			name:        "translated",
+			tracks:      tracksFinder{"en"},
+			translator:  stubTranslator{},
+			language:    "uk",
+			preferences: []string{"ru"},
+			translate:   true,
+			want:        "en>uk",
+			served:      Served{Requested: "uk", Language: "uk", Reason: language.Translated, Source: "en"},
+		},
+		{
+			name:       "translation is not requested",
+			tracks:     tracksFinder{"en"},
+			translator: stubTranslator{},
+			language:   "uk",
+			err:        ErrLanguageUnavailable,
+		},
+		{
+			name:      "no translator",
+			tracks:    tracksFinder{"en"},
+			language:  "uk",
+			translate: true,
+			err:       ErrLanguageUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			negotiator := NewNegotiator(tt.tracks, tt.translator)
+			request := models.VideoRequest{VideoID: "00000000001", Language: tt.language}
+
+			video, served, err := negotiator.Find(context.Background(), request, tt.preferences, tt.translate)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, video.Title)
+			assert.Equal(t, tt.served, served)
+		})
+	}
+}
+
+func TestNegotiatorFind_UpstreamFailure(t *testing.T) {
+	for _, err := range []error{ErrQuotaExceeded, ErrTimeout, ErrCircuitOpen, errors.New("conn closed")} {
+		t.Run(err.Error(), func(t *testing.T) {
+			finder := &failingFinder{err: err}
+			negotiator := NewNegotiator(finder, stubTranslator{})
+			request := models.VideoRequest{VideoID: "00000000001", Language: "uk"}
+
+			_, _, findErr := negotiator.Find(context.Background(), request, []string{"de", "fr"}, true)
+
+			assert.ErrorIs(t, findErr, err)
+			assert.Equal(t, 1, finder.calls, "fallback isn`t tried")
+		})
+	}
+}
+
+func TestNegotiatorFind_UnknownVideo(t *testing.T) {
+	var (
+		provider   = &stubProvider{name: "stub", err: &UpstreamError{Kind: ErrNotFound, Status: http.StatusNotFound}}
+		finder     = NewAPIFinder(NewRegistry(provider), &memoryVideos{videos: make(map[models.VideoRequest]*models.YTVideo)})
+		negotiator = NewNegotiator(finder, stubTranslator{})
+		request    = models.VideoRequest{VideoID: "00000000001", Language: "uk"}
+	)
+
+	_, _, err := negotiator.Find(context.Background(), request, []string{"de", "fr"}, true)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 1, provider.calls, "other languages aren`t requested")
+}
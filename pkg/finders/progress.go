@@ -0,0 +1,69 @@
+package finders
+
+import "context"
+
+// Stage of the video fetching pipeline
+type Stage string
+
+const (
+	StageCache       Stage = "cache"
+	StageProvider    Stage = "provider"
+	StageParse       Stage = "parse"
+	StagePersist     Stage = "persist"
+	StagePostProcess Stage = "postprocess"
+)
+
+// Status of the Stage
+const (
+	StatusStarted = "started"
+	StatusDone    = "done"
+	StatusHit     = "hit"
+	StatusMiss    = "miss"
+	StatusFailed  = "failed"
+	// StatusShared is reported when request joins concurrent lookup of the same video,
+	// then Progress of that lookup is reported
+	StatusShared = "shared"
+)
+
+// Progress is reported when pipeline stage changes its status. Code and Error describe StatusFailed.
+type Progress struct {
+	Stage    Stage     `json:"stage"`
+	Status   string    `json:"status"`
+	Provider string    `json:"provider,omitempty"`
+	Code     ErrorCode `json:"code,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// ProgressFunc must be safe for concurrent use
+type ProgressFunc func(Progress)
+
+type progressKey struct{}
+
+// WithProgress returns context which receives Progress of Finder.Find
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// Report sends progress to ProgressFunc of ctx if any
+func Report(ctx context.Context, progress Progress) {
+	if fn, ok := progressFunc(ctx); ok {
+		fn(progress)
+	}
+}
+
+func progressFunc(ctx context.Context) (ProgressFunc, bool) {
+	fn, ok := ctx.Value(progressKey{}).(ProgressFunc)
+
+	return fn, ok
+}
+
+// reportErr reports StatusFailed with err or StatusDone if err is nil
+func reportErr(ctx context.Context, progress Progress, err error) {
+	progress.Status = StatusDone
+	if err != nil {
+		progress.Status = StatusFailed
+		progress.Code, progress.Error = Describe(err)
+	}
+
+	Report(ctx, progress)
+}
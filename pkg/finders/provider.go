@@ -0,0 +1,153 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+)
+
+const (
+	RapidAPI  = "rapidapi"
+	Local     = "local"
+	TimedText = "timedtext"
+)
+
+var ErrNoProviders = errors.New("no transcript providers configured")
+
+type (
+	// Provider represents single source of YouTube video transcriptions.
+	Provider interface {
+		// Name uses to record which provider served the video
+		Name() string
+		Fetch(context.Context, models.VideoRequest) (*models.YTVideo, error)
+	}
+
+	// Registry keeps providers in priority order.
+	Registry struct {
+		providers []Provider
+	}
+
+	// ProviderError wraps error returned by the Provider
+	ProviderError struct {
+		Provider string
+		Err      error
+	}
+
+	// ChainError is returned when every provider in Registry failed
+	ChainError struct {
+		Errors []*ProviderError
+	}
+)
+
+func NewRegistry(providers ...Provider) *Registry {
+	return &Registry{providers: providers}
+}
+
+// NewRegistryFromConfig creates providers by names in the order of config.FinderConfiguration Providers.
+// Remote providers are wrapped in ResilientProvider.
+func NewRegistryFromConfig(client *http.Client, conf config.FinderConfiguration, logger *zap.Logger) (*Registry, error) {
+	registry := NewRegistry()
+
+	for _, name := range conf.Providers {
+		provider, err := NewProvider(name, client, conf)
+		if err != nil {
+			return nil, err
+		}
+		if name != Local {
+			provider = NewResilientProvider(provider, conf, logger)
+		}
+		registry.Register(provider)
+	}
+
+	return registry, nil
+}
+
+// NewProvider returns error if provider name is unknown
+func NewProvider(name string, client *http.Client, conf config.FinderConfiguration) (Provider, error) {
+	switch name {
+	case RapidAPI:
+		return NewRapidAPIProvider(client), nil
+	case Local:
+		return NewLocalProvider(conf.SubtitlesDir), nil
+	case TimedText:
+		return NewTimedTextProvider(client, conf.YouTubeURL), nil
+	default:
+		return nil, fmt.Errorf("unknown transcript provider %q", name)
+	}
+}
+
+// Register appends provider with the lowest priority
+func (r *Registry) Register(provider Provider) {
+	r.providers = append(r.providers, provider)
+}
+
+func (r *Registry) Providers() []Provider {
+	return r.providers
+}
+
+// Fetch walks providers in priority order until one succeeds.
+// Returns the video and the name of provider which served it.
+func (r *Registry) Fetch(ctx context.Context, request models.VideoRequest) (*models.YTVideo, string, error) {
+	if len(r.providers) == 0 {
+		return nil, "", ErrNoProviders
+	}
+
+	chain := &ChainError{}
+	for _, provider := range r.providers {
+		Report(ctx, Progress{Stage: StageProvider, Status: StatusStarted, Provider: provider.Name()})
+
+		video, err := provider.Fetch(ctx, request)
+		reportErr(ctx, Progress{Stage: StageProvider, Provider: provider.Name()}, err)
+		if err == nil {
+			return video, provider.Name(), nil
+		}
+
+		chain.Errors = append(chain.Errors, &ProviderError{Provider: provider.Name(), Err: err})
+
+		// do not try other providers when request is cancelled
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return nil, "", chain
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Provider, e.Err)
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ChainError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+
+	return "all transcript providers failed: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the most severe provider error, the first one of equally severe errors.
+// E.g. quota exceeded by the primary provider isn`t hidden by missing captions of the fallback one.
+func (e *ChainError) Unwrap() error {
+	var worst *ProviderError
+	for _, err := range e.Errors {
+		if worst == nil || severity(err) > severity(worst) {
+			worst = err
+		}
+	}
+
+	if worst == nil {
+		return nil
+	}
+
+	return worst
+}
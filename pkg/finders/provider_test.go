@@ -0,0 +1,90 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"transcribify/internal/models"
+)
+
+type stubProvider struct {
+	name  string
+	video *models.YTVideo
+	err   error
+	calls int
+}
+
+func (s *stubProvider) Name() string {
+	return s.name
+}
+
+func (s *stubProvider) Fetch(context.Context, models.VideoRequest) (*models.YTVideo, error) {
+	s.calls++
+	return s.video, s.err
+}
+
+func TestRegistry_Fetch(t *testing.T) {
+	var (
+		quota   = &UpstreamError{Kind: ErrQuotaExceeded}
+		request = models.VideoRequest{VideoID: "00000000000", Language: "en"}
+	)
+
+	t.Run("Fallback to next provider", func(t *testing.T) {
+		first := &stubProvider{name: "first", err: quota}
+		second := &stubProvider{name: "second", video: &models.YTVideo{Title: "Title"}}
+		third := &stubProvider{name: "third", video: &models.YTVideo{}}
+
+		video, provider, err := NewRegistry(first, second, third).Fetch(context.Background(), request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "second", provider)
+		assert.Equal(t, "Title", video.Title)
+		assert.Equal(t, 0, third.calls)
+	})
+
+	t.Run("All providers failed", func(t *testing.T) {
+		first := &stubProvider{name: "first", err: errors.New("outage")}
+		second := &stubProvider{name: "second", err: quota}
+
+		_, _, err := NewRegistry(first, second).Fetch(context.Background(), request)
+
+		var chain *ChainError
+		assert.ErrorAs(t, err, &chain)
+		assert.Len(t, chain.Errors, 2)
+		assert.ErrorIs(t, err, quota)
+	})
+
+	t.Run("Empty registry", func(t *testing.T) {
+		_, _, err := NewRegistry().Fetch(context.Background(), request)
+
+		assert.ErrorIs(t, err, ErrNoProviders)
+	})
+}
+
+func TestLocalProvider_Fetch(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "00000000000.en.json"),
+		[]byte(`{"title":"Local","transcription":[{"subtitle":"hi","start":1,"dur":2}]}`), 0o600)
+	assert.NoError(t, err)
+
+	provider := NewLocalProvider(dir)
+
+	video, err := provider.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000000", Language: "en"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Local", video.Title)
+	assert.Equal(t, []models.Transcription{{Subtitle: "hi", Start: 1, Dur: 2}}, video.Transcription)
+
+	err = os.WriteFile(filepath.Join(dir, "00000000000.fr.vtt"),
+		[]byte("WEBVTT\n\n00:01.000 --> 00:03.000\nsalut\n"), 0o600)
+	assert.NoError(t, err)
+
+	video, err = provider.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000000", Language: "fr"})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Transcription{{Subtitle: "salut", Start: 1, Dur: 2}}, video.Transcription)
+
+	_, err = provider.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000000", Language: "de"})
+	assert.Error(t, err)
+}
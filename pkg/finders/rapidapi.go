@@ -0,0 +1,149 @@
+package finders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"transcribify/internal/models"
+)
+
+// RapidAPIProvider fetches transcriptions from youtube-transcriptor RapidAPI host
+type RapidAPIProvider struct {
+	client  *http.Client
+	headers http.Header
+}
+
+func NewRapidAPIProvider(client *http.Client) *RapidAPIProvider {
+	return newRapidAPIProviderWithDefaultHeaders(client)
+}
+
+func newRapidAPIProviderWithDefaultHeaders(client *http.Client) *RapidAPIProvider {
+	headers := http.Header{}
+
+	headers.Add("X-RapidAPI-Key", os.Getenv("VIDEO_API_KEY"))
+	headers.Add("X-RapidAPI-Host", "youtube-transcriptor.p.rapidapi.com")
+
+	return NewRapidAPIProviderWithHeaders(client, headers)
+}
+
+func NewRapidAPIProviderWithHeaders(client *http.Client, headers http.Header) *RapidAPIProvider {
+	return &RapidAPIProvider{
+		client:  client,
+		headers: headers,
+	}
+}
+
+func (p *RapidAPIProvider) Name() string {
+	return RapidAPI
+}
+
+func (p *RapidAPIProvider) Fetch(ctx context.Context, video models.VideoRequest) (*models.YTVideo, error) {
+	var (
+		APIURL = fmt.Sprintf(
+			"https://youtube-transcriptor.p.rapidapi.com/transcript?video_id=%s&lang=%s",
+			video.VideoID,
+			video.Language,
+		)
+		data []models.YTVideo
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, APIURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = p.headers
+
+	response, err := p.client.Do(req)
+	if err != nil {
+		return nil, wrapTransportError(err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, 16<<20))
+	if err != nil {
+		return nil, wrapTransportError(err)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, newStatusError(response, body)
+	}
+
+	Report(ctx, Progress{Stage: StageParse, Status: StatusStarted, Provider: RapidAPI})
+	err = decodeRapidAPI(response, body, &data)
+	reportErr(ctx, Progress{Stage: StageParse, Provider: RapidAPI}, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, &UpstreamError{Kind: ErrNoCaptions, Status: response.StatusCode, Body: truncate(body)}
+	}
+
+	return &data[0], nil
+}
+
+// rapidAPIError is returned with 200 status for unknown videos and missing languages
+type rapidAPIError struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// decodeRapidAPI decodes array of videos. Error object is classified by its message.
+func decodeRapidAPI(response *http.Response, body []byte, data *[]models.YTVideo) error {
+	if mediaType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type")); mediaType != "" && mediaType != "application/json" {
+		return newMalformedError(response.StatusCode, body, fmt.Errorf("unexpected content type %q", mediaType))
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		var apiErr rapidAPIError
+		if err := json.Unmarshal(trimmed, &apiErr); err != nil {
+			return newMalformedError(response.StatusCode, body, err)
+		}
+
+		message := apiErr.Error
+		if message == "" {
+			message = apiErr.Message
+		}
+
+		return &UpstreamError{
+			Kind:   classifyMessage(message),
+			Status: response.StatusCode,
+			Body:   truncate(body),
+			Err:    errors.New(message),
+		}
+	}
+
+	if err := json.Unmarshal(trimmed, data); err != nil {
+		return newMalformedError(response.StatusCode, body, err)
+	}
+
+	return nil
+}
+
+// classifyMessage guesses kind of upstream error message
+func classifyMessage(message string) error {
+	message = strings.ToLower(message)
+
+	switch {
+	case strings.Contains(message, "quota"), strings.Contains(message, "rate limit"), strings.Contains(message, "too many"):
+		return ErrQuotaExceeded
+	case strings.Contains(message, "api key"), strings.Contains(message, "not subscribed"), strings.Contains(message, "unauthorized"):
+		return ErrUnauthorized
+	case strings.Contains(message, "subtitle"), strings.Contains(message, "caption"),
+		strings.Contains(message, "transcript"), strings.Contains(message, "language"):
+		return ErrNoCaptions
+	case strings.Contains(message, "not found"), strings.Contains(message, "invalid video"), strings.Contains(message, "unavailable"):
+		return ErrNotFound
+	default:
+		return ErrUpstream
+	}
+}
@@ -0,0 +1,147 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"github.com/cenkalti/backoff/v4"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+)
+
+// metrics of resilient providers are published in `/debug/vars` keyed by provider name
+var metrics = expvar.NewMap("transcript_providers")
+
+// ResilientProvider limits requests rate of the provider, retries failed requests
+// with jittered exponential backoff honouring Retry-After, and fails fast while the provider is unhealthy.
+type ResilientProvider struct {
+	provider    Provider
+	retries     int
+	interval    time.Duration
+	maxInterval time.Duration
+	breaker     *Breaker
+	limiter     *Limiter
+	logger      *zap.Logger
+	metrics     *expvar.Map
+}
+
+func NewResilientProvider(provider Provider, conf config.FinderConfiguration, logger *zap.Logger) *ResilientProvider {
+	r := &ResilientProvider{
+		provider:    provider,
+		retries:     conf.Retries,
+		interval:    conf.RetryInterval,
+		maxInterval: conf.MaxRetryInterval,
+		limiter:     NewLimiter(conf.RateLimit, conf.RateBurst),
+		logger:      logger.With(zap.String("provider", provider.Name())),
+		metrics:     new(expvar.Map).Init(),
+	}
+
+	r.breaker = NewBreaker(conf.BreakerFailures, conf.BreakerCooldown, func(from, to BreakerState) {
+		r.logger.Info("Transcript provider circuit breaker state changed",
+			zap.String("from", string(from)), zap.String("to", string(to)))
+		if to == BreakerOpen {
+			r.metrics.Add("breaker_opens", 1)
+		}
+	})
+
+	r.metrics.Set("breaker_state", expvar.Func(func() any { return r.breaker.State() }))
+	if r.limiter != nil {
+		r.metrics.Set("limiter_tokens", expvar.Func(func() any { return r.limiter.Tokens() }))
+	}
+	metrics.Set(provider.Name(), r.metrics)
+
+	return r
+}
+
+func (r *ResilientProvider) Name() string {
+	return r.provider.Name()
+}
+
+func (r *ResilientProvider) Fetch(ctx context.Context, request models.VideoRequest) (*models.YTVideo, error) {
+	policy := backoff.NewExponentialBackOff()
+	policy.InitialInterval = r.interval
+	policy.MaxInterval = r.maxInterval
+	policy.MaxElapsedTime = 0
+	policy.Reset()
+
+	for attempt := 0; ; attempt++ {
+		if err := r.breaker.Allow(); err != nil {
+			r.metrics.Add("rejected", 1)
+
+			return nil, err
+		}
+
+		wait, err := r.limiter.Wait(ctx)
+		if err != nil {
+			r.breaker.Cancel()
+
+			return nil, err
+		}
+		if wait > 0 {
+			r.metrics.Add("limiter_waits", 1)
+			r.metrics.Add("limiter_wait_ms", wait.Milliseconds())
+			r.logger.Info("Transcript provider rate limit reached", zap.Duration("waited", wait))
+		}
+
+		r.metrics.Add("requests", 1)
+		video, err := r.provider.Fetch(ctx, request)
+
+		switch {
+		case err == nil:
+			r.breaker.Success()
+
+			return video, nil
+		case ctx.Err() != nil:
+			r.breaker.Cancel()
+
+			return nil, err
+		case !isRetryable(err):
+			// provider is healthy, e.g. video has no captions
+			r.breaker.Success()
+
+			return nil, err
+		}
+
+		r.metrics.Add("failures", 1)
+		r.breaker.Failure()
+
+		delay := policy.NextBackOff()
+		var upstream *UpstreamError
+		if errors.As(err, &upstream) && upstream.RetryAfter > delay {
+			delay = upstream.RetryAfter
+		}
+
+		if attempt >= r.retries || delay > r.maxInterval {
+			return nil, err
+		}
+
+		r.metrics.Add("retries", 1)
+		r.logger.Info("Retrying transcript provider",
+			zap.Error(err), zap.Int("attempt", attempt+1), zap.Duration("delay", delay))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, err
+		}
+	}
+}
+
+// isRetryable reports whether error is a server failure (5xx), connection failure, quota exceeded (429) or timeout.
+// Other client errors (4xx) and error objects of successful responses won`t be fixed by retry.
+func isRetryable(err error) bool {
+	if errors.Is(err, ErrQuotaExceeded) || errors.Is(err, ErrTimeout) {
+		return true
+	}
+
+	var upstream *UpstreamError
+
+	return errors.As(err, &upstream) && errors.Is(upstream, ErrUpstream) &&
+		(upstream.Status == 0 || upstream.Status >= http.StatusInternalServerError)
+}
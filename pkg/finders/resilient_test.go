@@ -0,0 +1,237 @@
+package finders
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"testing"
+	"time"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+)
+
+// sequenceProvider returns errors in order, then the video
+type sequenceProvider struct {
+	errs  []error
+	calls int
+}
+
+func (s *sequenceProvider) Name() string {
+	return "sequence"
+}
+
+func (s *sequenceProvider) Fetch(context.Context, models.VideoRequest) (*models.YTVideo, error) {
+	s.calls++
+	if s.calls <= len(s.errs) {
+		return nil, s.errs[s.calls-1]
+	}
+
+	return &models.YTVideo{Title: "found"}, nil
+}
+
+func TestBreaker(t *testing.T) {
+	var (
+		now         = time.Unix(0, 0)
+		transitions []BreakerState
+	)
+
+	breaker := NewBreaker(2, time.Minute, func(_, to BreakerState) { transitions = append(transitions, to) })
+	breaker.now = func() time.Time { return now }
+
+	assert.NoError(t, breaker.Allow())
+	breaker.Failure()
+	assert.Equal(t, BreakerClosed, breaker.State())
+	breaker.Failure()
+	assert.Equal(t, BreakerOpen, breaker.State())
+
+	err := breaker.Allow()
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	var upstream *UpstreamError
+	if assert.ErrorAs(t, err, &upstream) {
+		assert.Equal(t, time.Minute, upstream.RetryAfter)
+	}
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, breaker.Allow())
+	assert.Equal(t, BreakerHalfOpen, breaker.State())
+	assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen, "single probe is allowed")
+
+	breaker.Failure()
+	assert.Equal(t, BreakerOpen, breaker.State(), "failed probe opens breaker again")
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, breaker.Allow())
+	breaker.Success()
+	assert.Equal(t, BreakerClosed, breaker.State())
+	assert.NoError(t, breaker.Allow())
+
+	assert.Equal(t, []BreakerState{BreakerOpen, BreakerHalfOpen, BreakerOpen, BreakerHalfOpen, BreakerClosed}, transitions)
+}
+
+func TestBreaker_Disabled(t *testing.T) {
+	breaker := NewBreaker(0, time.Minute, nil)
+
+	for i := 0; i < 10; i++ {
+		breaker.Failure()
+	}
+
+	assert.NoError(t, breaker.Allow())
+	assert.Equal(t, BreakerClosed, breaker.State())
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	limiter := NewLimiter(2, 2)
+	limiter.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+	assert.Equal(t, time.Second, limiter.reserve())
+	assert.Equal(t, -2.0, limiter.Tokens())
+
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, 2.0, limiter.Tokens())
+
+	now = now.Add(time.Hour)
+	assert.Equal(t, 2.0, limiter.Tokens(), "tokens are limited by burst")
+
+	limiter.tokens = -1
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := limiter.Wait(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, -1.0, limiter.Tokens(), "token of cancelled request is given back")
+
+	var unlimited *Limiter
+	wait, err := unlimited.Wait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), wait)
+}
+
+func TestResilientProvider_Fetch(t *testing.T) {
+	var (
+		serverErr = &UpstreamError{Kind: ErrUpstream, Status: http.StatusBadGateway}
+		quotaErr  = &UpstreamError{Kind: ErrQuotaExceeded, Status: http.StatusTooManyRequests, RetryAfter: 20 * time.Millisecond}
+		longQuota = &UpstreamError{Kind: ErrQuotaExceeded, Status: http.StatusTooManyRequests, RetryAfter: time.Hour}
+		notFound  = &UpstreamError{Kind: ErrNotFound, Status: http.StatusNotFound}
+		connErr   = &UpstreamError{Kind: ErrUpstream, Err: errors.New("connection refused")}
+		// error object of 200 response
+		errObject  = &UpstreamError{Kind: ErrUpstream, Status: http.StatusOK}
+		badRequest = newStatusError(&http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{}}, []byte(`{"message":"bad request"}`))
+	)
+
+	tests := []struct {
+		name     string
+		errs     []error
+		calls    int
+		minDelay time.Duration
+		err      error
+	}{
+		{
+			name:  "Retries server errors",
+			errs:  []error{serverErr, serverErr},
+			calls: 3,
+		},
+		{
+			name:     "Honours Retry-After",
+			errs:     []error{quotaErr},
+			calls:    2,
+			minDelay: 20 * time.Millisecond,
+		},
+		{
+			name:  "Gives up after retries",
+			errs:  []error{serverErr, serverErr, serverErr},
+			calls: 3,
+			err:   ErrUpstream,
+		},
+		{
+			name:  "Gives up if Retry-After is too long",
+			errs:  []error{longQuota},
+			calls: 1,
+			err:   ErrQuotaExceeded,
+		},
+		{
+			name:  "Retries connection failure",
+			errs:  []error{connErr},
+			calls: 2,
+		},
+		{
+			name:  "Doesn`t retry bad request",
+			errs:  []error{badRequest},
+			calls: 1,
+			err:   ErrUpstream,
+		},
+		{
+			name:  "Doesn`t retry error object",
+			errs:  []error{errObject},
+			calls: 1,
+			err:   ErrUpstream,
+		},
+		{
+			name:  "Doesn`t retry not found video",
+			errs:  []error{notFound},
+			calls: 1,
+			err:   ErrNotFound,
+		},
+	}
+
+	conf := config.FinderConfiguration{
+		Retries:          2,
+		RetryInterval:    time.Millisecond,
+		MaxRetryInterval: time.Second,
+		BreakerFailures:  10,
+		BreakerCooldown:  time.Minute,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &sequenceProvider{errs: tt.errs}
+			resilient := NewResilientProvider(provider, conf, zap.NewNop())
+
+			start := time.Now()
+			video, err := resilient.Fetch(context.Background(), models.VideoRequest{VideoID: "id", Language: "en"})
+
+			assert.Equal(t, tt.calls, provider.calls)
+			assert.Equal(t, BreakerClosed, resilient.breaker.State())
+			assert.GreaterOrEqual(t, time.Since(start), tt.minDelay)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "found", video.Title)
+		})
+	}
+}
+
+func TestResilientProvider_FailsFast(t *testing.T) {
+	serverErr := &UpstreamError{Kind: ErrUpstream, Status: http.StatusInternalServerError}
+	provider := &sequenceProvider{errs: []error{serverErr, serverErr, serverErr}}
+
+	resilient := NewResilientProvider(provider, config.FinderConfiguration{
+		Retries:          5,
+		RetryInterval:    time.Millisecond,
+		MaxRetryInterval: time.Second,
+		BreakerFailures:  2,
+		BreakerCooldown:  time.Minute,
+	}, zap.NewNop())
+
+	_, err := resilient.Fetch(context.Background(), models.VideoRequest{VideoID: "id", Language: "en"})
+
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, provider.calls)
+	assert.Equal(t, BreakerOpen, resilient.breaker.State())
+
+	code, status := Classify(err)
+	assert.Equal(t, CodeUnavailable, code)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	_, err = resilient.Fetch(context.Background(), models.VideoRequest{VideoID: "id", Language: "en"})
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(t, 2, provider.calls, "open breaker doesn`t call provider")
+}
@@ -0,0 +1,236 @@
+package finders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"transcribify/internal/models"
+	"transcribify/pkg/language"
+	"transcribify/pkg/subtitles"
+)
+
+// DefaultYouTubeURL is the base URL of watch pages
+const DefaultYouTubeURL = "https://www.youtube.com"
+
+var (
+	ErrNoPlayerResponse = fmt.Errorf("%w: player response not found in the watch page", ErrMalformed)
+	ErrUnplayable       = fmt.Errorf("%w: video is unplayable", ErrNotFound)
+)
+
+// playerResponseMarker precedes player response object in the watch page
+const playerResponseMarker = "ytInitialPlayerResponse = "
+
+// TimedTextProvider discovers caption tracks in the player response of the watch page
+// and downloads them from timedtext API in json3 format. XML response is accepted as well.
+// It needs no API key.
+type TimedTextProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+type (
+	playerResponse struct {
+		PlayabilityStatus struct {
+			Status string `json:"status"`
+			Reason string `json:"reason"`
+		} `json:"playabilityStatus"` //nolint:tagliatelle
+		VideoDetails struct {
+			Title            string `json:"title"`
+			ShortDescription string `json:"shortDescription"` //nolint:tagliatelle
+			LengthSeconds    string `json:"lengthSeconds"`    //nolint:tagliatelle
+			Thumbnail        struct {
+				Thumbnails []models.Thumbnails `json:"thumbnails"`
+			} `json:"thumbnail"`
+		} `json:"videoDetails"` //nolint:tagliatelle
+		Captions struct {
+			Renderer struct {
+				CaptionTracks []captionTrack `json:"captionTracks"` //nolint:tagliatelle
+			} `json:"playerCaptionsTracklistRenderer"` //nolint:tagliatelle
+		} `json:"captions"`
+	}
+
+	captionTrack struct {
+		BaseURL      string `json:"baseUrl"`      //nolint:tagliatelle
+		LanguageCode string `json:"languageCode"` //nolint:tagliatelle
+		// Kind is `asr` for automatic captions
+		Kind string `json:"kind"`
+	}
+)
+
+func NewTimedTextProvider(client *http.Client, baseURL string) *TimedTextProvider {
+	if baseURL == "" {
+		baseURL = DefaultYouTubeURL
+	}
+
+	return &TimedTextProvider{
+		client:  client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (p *TimedTextProvider) Name() string {
+	return TimedText
+}
+
+func (p *TimedTextProvider) Fetch(ctx context.Context, request models.VideoRequest) (*models.YTVideo, error) {
+	player, err := p.player(ctx, request.VideoID)
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := player.Captions.Renderer.CaptionTracks
+
+	video := &models.YTVideo{
+		Title:           html.UnescapeString(player.VideoDetails.Title),
+		Description:     html.UnescapeString(player.VideoDetails.ShortDescription),
+		LengthInSeconds: player.VideoDetails.LengthSeconds,
+		Thumbnails:      player.VideoDetails.Thumbnail.Thumbnails,
+		AvailableLangs:  trackLanguages(tracks),
+	}
+
+	track, ok := selectTrack(tracks, request.Language)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q, available: %v", ErrNoCaptions, request.Language, video.AvailableLangs)
+	}
+
+	video.Transcription, err = p.transcription(ctx, track)
+	if err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
+
+func (p *TimedTextProvider) player(ctx context.Context, videoID string) (*playerResponse, error) {
+	page, err := p.get(ctx, p.baseURL+"/watch?v="+url.QueryEscape(videoID)+"&hl=en")
+	if err != nil {
+		return nil, err
+	}
+
+	i := bytes.Index(page, []byte(playerResponseMarker))
+	if i == -1 {
+		return nil, ErrNoPlayerResponse
+	}
+
+	Report(ctx, Progress{Stage: StageParse, Status: StatusStarted, Provider: TimedText})
+	// decoder stops after the object, so the rest of the script is ignored
+	player := new(playerResponse)
+	err = json.NewDecoder(bytes.NewReader(page[i+len(playerResponseMarker):])).Decode(player)
+	reportErr(ctx, Progress{Stage: StageParse, Provider: TimedText}, err)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoPlayerResponse, err)
+	}
+
+	if status := player.PlayabilityStatus.Status; status != "" && status != "OK" {
+		return nil, fmt.Errorf("%w: %s %s", ErrUnplayable, status, player.PlayabilityStatus.Reason)
+	}
+
+	return player, nil
+}
+
+func (p *TimedTextProvider) transcription(ctx context.Context, track captionTrack) ([]models.Transcription, error) {
+	link, err := url.Parse(track.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := url.Parse(p.baseURL + "/")
+	if err != nil {
+		return nil, err
+	}
+	link = base.ResolveReference(link)
+
+	query := link.Query()
+	query.Set("fmt", "json3")
+	link.RawQuery = query.Encode()
+
+	data, err := p.get(ctx, link.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var transcription []models.Transcription
+
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("<")) {
+		transcription, err = subtitles.ParseTimedText(data)
+	} else {
+		transcription, err = subtitles.ParseJSON3(data)
+	}
+	if err != nil {
+		return nil, newMalformedError(http.StatusOK, data, err)
+	}
+
+	// track without text events must not be cached as a video
+	if len(transcription) == 0 {
+		return nil, &UpstreamError{Kind: ErrNoCaptions, Status: http.StatusOK, Body: truncate(data)}
+	}
+
+	return transcription, nil
+}
+
+func (p *TimedTextProvider) get(ctx context.Context, link string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	// skips consent page in EU
+	req.AddCookie(&http.Cookie{Name: "CONSENT", Value: "YES+1"})
+
+	response, err := p.client.Do(req)
+	if err != nil {
+		return nil, wrapTransportError(err)
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, 16<<20))
+	if err != nil {
+		return nil, wrapTransportError(err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newStatusError(response, data)
+	}
+
+	return data, nil
+}
+
+// selectTrack prefers manual captions to automatic ones. Regional variants are left to Negotiator.
+func selectTrack(tracks []captionTrack, lang string) (captionTrack, bool) {
+	found, ok := captionTrack{}, false
+
+	for _, t := range tracks {
+		if !language.Equal(t.LanguageCode, lang) {
+			continue
+		}
+		if t.Kind != "asr" {
+			return t, true
+		}
+		found, ok = t, true
+	}
+
+	return found, ok
+}
+
+func trackLanguages(tracks []captionTrack) []string {
+	var (
+		languages = make([]string, 0, len(tracks))
+		seen      = make(map[string]bool)
+	)
+
+	for _, t := range tracks {
+		if !seen[t.LanguageCode] {
+			seen[t.LanguageCode] = true
+			languages = append(languages, t.LanguageCode)
+		}
+	}
+
+	return languages
+}
@@ -0,0 +1,109 @@
+package finders
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"transcribify/internal/models"
+)
+
+const watchPage = `<html><script>var ytInitialPlayerResponse = {
+"playabilityStatus": {"status": "OK"},
+"videoDetails": {"videoId": "00000000001", "title": "Tom &amp; Jerry", "shortDescription": "about", "lengthSeconds": "42",
+  "thumbnail": {"thumbnails": [{"url": "https://i.ytimg.com/1.jpg", "width": 120, "height": 90}]}},
+"captions": {"playerCaptionsTracklistRenderer": {"captionTracks": [
+  {"baseUrl": "%[1]s/api/timedtext?v=00000000001&lang=en&kind=asr", "languageCode": "en", "kind": "asr"},
+  {"baseUrl": "%[1]s/api/timedtext?v=00000000001&lang=en", "languageCode": "en"},
+  {"baseUrl": "/api/timedtext?v=00000000001&lang=de", "languageCode": "de"},
+  {"baseUrl": "/api/timedtext?v=00000000001&lang=es", "languageCode": "es"},
+  {"baseUrl": "/api/timedtext?v=00000000001&lang=it", "languageCode": "it"}
+]}}};var meta = {"a": "}"};</script></html>`
+
+func timedTextServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		switch {
+		case r.URL.Path == "/watch" && query.Get("v") == "00000000001":
+			fmt.Fprintf(w, watchPage, server.URL)
+		case r.URL.Path == "/watch" && query.Get("v") == "00000000002":
+			fmt.Fprint(w, `<script>var ytInitialPlayerResponse = {"playabilityStatus": {"status": "ERROR", "reason": "Video unavailable"}};</script>`)
+		case r.URL.Path == "/watch":
+			fmt.Fprint(w, `<html>consent</html>`)
+		case r.URL.Path == "/api/timedtext" && query.Get("kind") == "asr":
+			fmt.Fprint(w, `{"events": [{"tStartMs": 0, "dDurationMs": 1000, "segs": [{"utf8": "automatic"}]}]}`)
+		case r.URL.Path == "/api/timedtext" && query.Get("lang") == "en" && query.Get("fmt") == "json3":
+			fmt.Fprint(w, `{"events": [{"tStartMs": 0, "dDurationMs": 5000},
+				{"tStartMs": 500, "dDurationMs": 1500, "segs": [{"utf8": "it&#39;s "}, {"utf8": "manual"}]},
+				{"tStartMs": 2000, "segs": [{"utf8": "\n"}]}]}`)
+		case r.URL.Path == "/api/timedtext" && query.Get("lang") == "de":
+			fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8" ?><transcript><text start="1.5" dur="2">Guten &amp;amp; Tag</text></transcript>`)
+		case r.URL.Path == "/api/timedtext" && query.Get("lang") == "es":
+			fmt.Fprint(w, `{"events": [{"tStartMs": 0, "dDurationMs": 5000}, {"tStartMs": 0, "segs": [{"utf8": "\n"}]}]}`)
+		case r.URL.Path == "/api/timedtext" && query.Get("lang") == "it":
+			fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8" ?><transcript></transcript>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server
+}
+
+func TestTimedTextProvider_Fetch(t *testing.T) {
+	server := timedTextServer(t)
+	defer server.Close()
+
+	provider := NewTimedTextProvider(server.Client(), server.URL+"/")
+
+	t.Run("json3 manual captions", func(t *testing.T) {
+		video, err := provider.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000001", Language: "en"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Tom & Jerry", video.Title)
+		assert.Equal(t, "42", video.LengthInSeconds)
+		assert.Equal(t, []string{"en", "de", "es", "it"}, video.AvailableLangs)
+		assert.Equal(t, []models.Thumbnails{{Url: "https://i.ytimg.com/1.jpg", Width: 120, Height: 90}}, video.Thumbnails)
+		assert.Equal(t, []models.Transcription{{Subtitle: "it's manual", Start: 0.5, Dur: 1.5}}, video.Transcription)
+	})
+
+	t.Run("xml with relative track url", func(t *testing.T) {
+		video, err := provider.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000001", Language: "de"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Transcription{{Subtitle: "Guten & Tag", Start: 1.5, Dur: 2}}, video.Transcription)
+	})
+
+	t.Run("no captions in the language", func(t *testing.T) {
+		_, err := provider.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000001", Language: "fr"})
+
+		assert.ErrorIs(t, err, ErrNoCaptions)
+	})
+
+	t.Run("track without text", func(t *testing.T) {
+		for _, language := range []string{"es", "it"} {
+			video, err := provider.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000001", Language: language})
+
+			assert.Nil(t, video)
+			assert.ErrorIs(t, err, ErrNoCaptions, language)
+		}
+	})
+
+	t.Run("unplayable", func(t *testing.T) {
+		_, err := provider.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000002", Language: "en"})
+
+		assert.ErrorIs(t, err, ErrUnplayable)
+	})
+
+	t.Run("no player response", func(t *testing.T) {
+		_, err := provider.Fetch(context.Background(), models.VideoRequest{VideoID: "00000000003", Language: "en"})
+
+		assert.ErrorIs(t, err, ErrNoPlayerResponse)
+	})
+}
@@ -0,0 +1,172 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"time"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+	"transcribify/pkg/finders"
+	"transcribify/pkg/repository"
+	"transcribify/pkg/webhook"
+)
+
+// Timeout of the single job processing
+const Timeout = 2 * time.Minute
+
+// Pool runs queued jobs stored in repository with bounded concurrency.
+// Jobs are claimed from repository, so they survive restart.
+type Pool struct {
+	jobs   repository.Job
+	users  repository.User
+	finder finders.Finder
+	notify webhook.Notifier
+	logger *zap.Logger
+
+	poll  time.Duration
+	slots chan struct{}
+	wake  chan struct{}
+}
+
+func NewPool(
+	jobs repository.Job,
+	users repository.User,
+	finder finders.Finder,
+	notify webhook.Notifier,
+	logger *zap.Logger,
+	conf config.JobsConfiguration,
+) *Pool {
+	return &Pool{
+		jobs:   jobs,
+		users:  users,
+		finder: finder,
+		notify: notify,
+		logger: logger,
+		poll:   conf.PollInterval,
+		slots:  make(chan struct{}, conf.Workers),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Start returns interrupted jobs to the queue and starts dispatching until ctx is done
+func (p *Pool) Start(ctx context.Context) error {
+	n, err := p.jobs.RequeueRunning(ctx)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		p.logger.Info("Requeued interrupted jobs", zap.Int64("count", n))
+	}
+
+	go p.dispatch(ctx)
+
+	return nil
+}
+
+// Enqueue stores the job and wakes up dispatcher
+func (p *Pool) Enqueue(ctx context.Context, uid int, request models.VideoRequest) (*models.Job, error) {
+	job, err := p.jobs.CreateJob(ctx, uid, request)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+
+	return job, nil
+}
+
+func (p *Pool) dispatch(ctx context.Context) {
+	ticker := time.NewTicker(p.poll)
+	defer ticker.Stop()
+
+	for {
+		// wait for free worker
+		select {
+		case p.slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+
+		job, err := p.jobs.ClaimJob(ctx)
+		if err == nil {
+			go func() {
+				defer func() { <-p.slots }()
+				p.process(ctx, job)
+			}()
+
+			continue
+		}
+
+		<-p.slots
+		if !errors.Is(err, pgx.ErrNoRows) {
+			p.logger.Info("Failed to claim job", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Pool) process(ctx context.Context, job *models.Job) {
+	var (
+		request = models.VideoRequest{VideoID: job.VideoID, Language: job.Language}
+		logger  = p.logger.With(zap.Int("job", job.ID), zap.Any("video request", request))
+	)
+
+	jobCtx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	logger.Info("Job started", zap.Int("attempt", job.Attempts))
+
+	video, err := p.finder.Find(jobCtx, request)
+	if err == nil {
+		err = p.users.PutUserVideo(jobCtx, job.UserID, video.Id)
+	}
+
+	resultID := -1
+	if err == nil {
+		resultID = video.Id
+	}
+
+	// job is left running and will be requeued after restart
+	if ctx.Err() != nil {
+		return
+	}
+
+	if finishErr := p.jobs.FinishJob(ctx, job.ID, resultID, err); finishErr != nil {
+		logger.Info("Failed to finish job", zap.Error(finishErr))
+
+		return
+	}
+
+	event := models.VideoEvent{JobID: job.ID, VideoID: job.VideoID, Language: job.Language}
+
+	if err != nil {
+		logger.Info("Job failed", zap.Error(err))
+
+		event.Error = err.Error()
+		p.notify.Notify(ctx, job.UserID, models.EventVideoFailed, event)
+
+		return
+	}
+	logger.Info("Job succeeded", zap.Int("video.Id", video.Id))
+
+	event.Title = video.Title
+	event.Result = ResultLink(job.VideoID, job.Language)
+	p.notify.Notify(ctx, job.UserID, models.EventVideoCompleted, event)
+}
+
+// ResultLink returns video endpoint path for succeeded job
+func ResultLink(videoID, language string) string {
+	return fmt.Sprintf("/api/v1/video/%s?lang=%s", videoID, language)
+}
@@ -0,0 +1,140 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+	"time"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+	"transcribify/pkg/repository"
+)
+
+type memoryJobs struct {
+	mu   sync.Mutex
+	jobs []*models.Job
+	done chan *models.Job
+}
+
+func (m *memoryJobs) CreateJob(_ context.Context, uid int, request models.VideoRequest) (*models.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job := &models.Job{ID: len(m.jobs) + 1, UserID: uid, VideoID: request.VideoID, Language: request.Language, State: models.JobQueued}
+	m.jobs = append(m.jobs, job)
+
+	return job, nil
+}
+
+func (m *memoryJobs) GetJob(_ context.Context, id int) (*models.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.jobs[id-1], nil
+}
+
+func (m *memoryJobs) ClaimJob(context.Context) (*models.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, job := range m.jobs {
+		if job.State == models.JobQueued {
+			job.State = models.JobRunning
+			job.Attempts++
+			c := *job
+			return &c, nil
+		}
+	}
+
+	return nil, pgx.ErrNoRows
+}
+
+func (m *memoryJobs) FinishJob(_ context.Context, id int, resultID int, jobErr error) error {
+	m.mu.Lock()
+	job := m.jobs[id-1]
+	job.State, job.ResultID = models.JobSucceeded, resultID
+	if jobErr != nil {
+		job.State, job.Error = models.JobFailed, jobErr.Error()
+	}
+	c := *job
+	m.mu.Unlock()
+
+	m.done <- &c
+	return nil
+}
+
+func (m *memoryJobs) RequeueRunning(context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var n int64
+	for _, job := range m.jobs {
+		if job.State == models.JobRunning {
+			job.State = models.JobQueued
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+type stubUsers struct {
+	repository.User
+}
+
+func (stubUsers) PutUserVideo(context.Context, int, int) error {
+	return nil
+}
+
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(context.Context, int, string, any) {}
+
+type stubFinder struct{}
+
+func (stubFinder) Find(_ context.Context, request models.VideoRequest) (*models.YTVideo, error) {
+	if request.Language == "de" {
+		return nil, errors.New("no captions")
+	}
+
+	return &models.YTVideo{Id: 42}, nil
+}
+
+func TestPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := &memoryJobs{done: make(chan *models.Job, 3)}
+	// interrupted by restart
+	repo.jobs = append(repo.jobs, &models.Job{ID: 1, UserID: 1, VideoID: "00000000000", Language: "en", State: models.JobRunning})
+
+	pool := NewPool(repo, stubUsers{}, stubFinder{}, nopNotifier{}, zap.NewNop(),
+		config.JobsConfiguration{Workers: 2, PollInterval: time.Hour})
+
+	assert.NoError(t, pool.Start(ctx))
+
+	_, err := pool.Enqueue(ctx, 1, models.VideoRequest{VideoID: "00000000001", Language: "en"})
+	assert.NoError(t, err)
+	_, err = pool.Enqueue(ctx, 1, models.VideoRequest{VideoID: "00000000002", Language: "de"})
+	assert.NoError(t, err)
+
+	finished := make(map[int]*models.Job)
+	for len(finished) < 3 {
+		select {
+		case job := <-repo.done:
+			finished[job.ID] = job
+		case <-time.After(5 * time.Second):
+			t.Fatal("jobs are not finished")
+		}
+	}
+
+	assert.Equal(t, models.JobSucceeded, finished[1].State)
+	assert.Equal(t, 42, finished[1].ResultID)
+	assert.Equal(t, models.JobSucceeded, finished[2].State)
+	assert.Equal(t, models.JobFailed, finished[3].State)
+	assert.Equal(t, "no captions", finished[3].Error)
+}
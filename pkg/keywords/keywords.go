@@ -0,0 +1,190 @@
+package keywords
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"transcribify/internal/models"
+	"unicode"
+)
+
+const (
+	// MaxPhraseWords is the longest keyphrase
+	MaxPhraseWords = 3
+	// DefaultLimit of keywords per video
+	DefaultLimit = 10
+)
+
+// Stats is a document frequency of terms in the stored transcriptions of the same language
+type Stats struct {
+	Documents int
+	DF        map[string]int
+}
+
+// IDF is smoothed, so unknown terms and empty corpus are handled
+func (s Stats) IDF(term string) float64 {
+	return math.Log(float64(s.Documents+1)/float64(s.DF[term]+1)) + 1
+}
+
+// Extract returns at most limit keyphrases of the text ordered by descending score in (0, 1].
+//
+// Candidates are RAKE phrases: runs of up to MaxPhraseWords words between stopwords and punctuation.
+// Word score is degree/frequency, phrase RAKE score is the sum of its words scores.
+// Phrase score is RAKE score * mean IDF of its words * phrase frequency,
+// so phrases frequent in the video and rare in the corpus come first.
+// Phrases contained in a better phrase are skipped.
+func Extract(text, language string, stats Stats, limit int) []models.Keyword {
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+
+	phrases := Candidates(text, Stopwords(language))
+
+	var (
+		freq   = make(map[string]int)
+		degree = make(map[string]int)
+		count  = make(map[string]int)
+		words  = make(map[string][]string)
+	)
+
+	for _, p := range phrases {
+		key := strings.Join(p, " ")
+		count[key]++
+		words[key] = p
+
+		for _, w := range p {
+			freq[w]++
+			degree[w] += len(p)
+		}
+	}
+
+	type scored struct {
+		phrase string
+		score  float64
+	}
+
+	candidates := make([]scored, 0, len(count))
+	for key, n := range count {
+		var rake, idf float64
+		for _, w := range words[key] {
+			rake += float64(degree[w]) / float64(freq[w])
+			idf += stats.IDF(w)
+		}
+		idf /= float64(len(words[key]))
+
+		candidates = append(candidates, scored{phrase: key, score: rake * idf * float64(n)})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].phrase < candidates[j].phrase
+	})
+
+	keywords := make([]models.Keyword, 0, limit)
+	for _, c := range candidates {
+		if len(keywords) == limit {
+			break
+		}
+
+		contained := false
+		for _, k := range keywords {
+			if strings.Contains(" "+k.Keyword+" ", " "+c.phrase+" ") {
+				contained = true
+				break
+			}
+		}
+		if contained {
+			continue
+		}
+
+		keywords = append(keywords, models.Keyword{Keyword: c.phrase, Score: c.score})
+	}
+
+	if len(keywords) > 0 && keywords[0].Score > 0 {
+		top := keywords[0].Score
+		for i := range keywords {
+			keywords[i].Score = math.Round(keywords[i].Score/top*1000) / 1000
+		}
+	}
+
+	return keywords
+}
+
+// Candidates splits text into phrases at punctuation and stopwords.
+// Phrases longer than MaxPhraseWords and words without letters are dropped.
+func Candidates(text string, stopwords map[string]bool) [][]string {
+	var (
+		phrases [][]string
+		phrase  []string
+	)
+
+	flush := func() {
+		if len(phrase) > 0 && len(phrase) <= MaxPhraseWords {
+			phrases = append(phrases, phrase)
+		}
+		phrase = nil
+	}
+
+	for _, fragment := range strings.FieldsFunc(strings.ToLower(text), isPunct) {
+		for _, w := range strings.Fields(fragment) {
+			w = strings.Trim(w, "'’")
+			if w == "" || stopwords[w] || len([]rune(w)) < 2 || !hasLetter(w) {
+				flush()
+				continue
+			}
+			phrase = append(phrase, w)
+		}
+		flush()
+	}
+
+	return phrases
+}
+
+// Terms returns distinct words of the text the same way IDF statistics are computed
+func Terms(text string) []string {
+	var (
+		terms []string
+		seen  = make(map[string]bool)
+	)
+
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return isPunct(r) || unicode.IsSpace(r)
+	}) {
+		w = strings.Trim(w, "'’")
+		if w != "" && !seen[w] {
+			seen[w] = true
+			terms = append(terms, w)
+		}
+	}
+
+	return terms
+}
+
+// Transcript joins segments subtitles. Segments end is treated as punctuation, because
+// automatic captions are split at pauses.
+func Transcript(transcription []models.Transcription) string {
+	parts := make([]string, 0, len(transcription))
+	for _, t := range transcription {
+		if s := strings.TrimSpace(t.Subtitle); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func isPunct(r rune) bool {
+	return (!unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r) && r != '\'' && r != '’') || r == '\n'
+}
+
+func hasLetter(w string) bool {
+	for _, r := range w {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package keywords
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+	"transcribify/pkg/repository"
+)
+
+func TestStopwords(t *testing.T) {
+	assert.True(t, Stopwords("en")["the"])
+	assert.True(t, Stopwords("en-GB")["the"])
+	assert.True(t, Stopwords("RU")["это"])
+	assert.Empty(t, Stopwords("xx"))
+}
+
+func TestCandidates(t *testing.T) {
+	phrases := Candidates("Today we deploy the Docker Compose stack, and it's 2023 now. One two three four words",
+		Stopwords("en"))
+
+	assert.Equal(t, [][]string{
+		{"today"},
+		{"deploy"},
+		{"docker", "compose", "stack"},
+	}, phrases)
+}
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"docker's", "compose", "2023"}, Terms("Docker's compose, compose! 2023"))
+}
+
+func TestExtract(t *testing.T) {
+	text := "We use docker compose for the database.\n" +
+		"Docker compose starts the database container.\n" +
+		"Then the database migrations run with docker compose.\n" +
+		"Video ends"
+
+	stats := Stats{Documents: 100, DF: map[string]int{
+		"video": 90, "ends": 40, "run": 60, "starts": 50, "container": 20, "migrations": 3, "database": 5, "docker": 10, "compose": 10,
+	}}
+
+	keywords := Extract(text, "en", stats, 3)
+
+	assert.Len(t, keywords, 3)
+	assert.Equal(t, models.Keyword{Keyword: "docker compose", Score: 1}, keywords[0])
+	assert.Equal(t, "database migrations run", keywords[1].Keyword)
+	for _, k := range keywords {
+		assert.NotEqual(t, "docker", k.Keyword)
+		assert.NotEqual(t, "compose", k.Keyword)
+	}
+
+	assert.Empty(t, Extract("", "en", Stats{}, 3))
+}
+
+type memoryKeywords struct {
+	repository.Keyword
+
+	stored    map[int][]models.Keyword
+	untagged  []models.VideoRequest
+	indexed   int
+	actual    int
+	refreshed int
+}
+
+func (m *memoryKeywords) GetKeywords(_ context.Context, videoID int) ([]models.Keyword, error) {
+	if keywords, ok := m.stored[videoID]; ok {
+		return keywords, nil
+	}
+
+	return nil, pgx.ErrNoRows
+}
+
+func (m *memoryKeywords) PutKeywords(_ context.Context, videoID int, keywords []models.Keyword) error {
+	m.stored[videoID] = keywords
+
+	return nil
+}
+
+func (m *memoryKeywords) GetStats(context.Context, string, []string) (int, map[string]int, error) {
+	return 0, map[string]int{}, nil
+}
+
+func (m *memoryKeywords) CorpusSize(context.Context) (int, int, error) {
+	return m.indexed, m.actual, nil
+}
+
+func (m *memoryKeywords) RefreshStats(context.Context) (int, error) {
+	m.refreshed++
+	m.indexed = m.actual
+
+	return m.actual, nil
+}
+
+func (m *memoryKeywords) GetUntagged(context.Context, int) ([]models.VideoRequest, error) {
+	return m.untagged, nil
+}
+
+type memoryVideos struct {
+	repository.Video
+
+	videos map[models.VideoRequest]*models.YTVideo
+}
+
+func (m *memoryVideos) GetVideoByIDLang(_ context.Context, request models.VideoRequest) (*models.YTVideo, error) {
+	if video, ok := m.videos[request]; ok {
+		return video, nil
+	}
+
+	return nil, pgx.ErrNoRows
+}
+
+func TestTagger(t *testing.T) {
+	var (
+		request = models.VideoRequest{VideoID: "00000000000", Language: "en"}
+		video   = &models.YTVideo{Id: 1, Transcription: []models.Transcription{{Subtitle: "kubernetes operators"}}}
+		repo    = &memoryKeywords{stored: map[int][]models.Keyword{}, untagged: []models.VideoRequest{request}, actual: 1}
+		videos  = &memoryVideos{videos: map[models.VideoRequest]*models.YTVideo{request: video}}
+		conf    = config.KeywordsConfiguration{Batch: 10, Growth: 0.1}
+		tagger  = NewTagger(repo, videos, zap.NewNop(), conf)
+		ctx     = context.Background()
+	)
+
+	tagger.refresh(ctx)
+	assert.Equal(t, 1, repo.refreshed)
+
+	// 1 -> 1 video isn`t a growth
+	tagger.refresh(ctx)
+	assert.Equal(t, 1, repo.refreshed)
+
+	tagger.backfill(ctx)
+	assert.Equal(t, []models.Keyword{{Keyword: "kubernetes operators", Score: 1}}, repo.stored[1])
+
+	repo.stored[1] = []models.Keyword{{Keyword: "cached", Score: 1}}
+	keywords, err := tagger.Keywords(ctx, video, "en")
+	assert.NoError(t, err)
+	assert.Equal(t, "cached", keywords[0].Keyword)
+}
@@ -0,0 +1,64 @@
+package keywords
+
+import "strings"
+
+// stopwords by ISO 639-1 language code. Transcript fillers are included.
+var stopwords = map[string]map[string]bool{
+	"en": toSet(`a about above after again against all also am an and any are aren't as at be because been before being
+below between both but by can can't cannot could couldn't did didn't do does doesn't doing don't down during each few
+for from further get gets getting going gonna got had hadn't has hasn't have haven't having he he'd he'll he's her
+here here's hers herself him himself his how how's i i'd i'll i'm i've if in into is isn't it it's its itself just
+know let's like lot make me more most much mustn't my myself no nor not now of off on once one only or other ought our
+ours ourselves out over own really right same say see shan't she she'd she'll she's should shouldn't so some something
+such than that that's the their theirs them themselves then there there's these they they'd they'll they're they've
+thing things think this those through to too under until up use used using very want was wasn't way we we'd we'll we're we've well
+were weren't what what's when when's where where's which while who who's whom why why's will with won't would wouldn't
+yeah yes you you'd you'll you're you've your yours yourself yourselves actually okay oh uh um music applause laughter`),
+	"ru": toSet(`а без более бы был была были было быть в вам вас весь во вот все всего всех вы где да даже для до его
+ее если есть еще же за здесь и из или им их к как ко когда кто ли либо мне может мы на надо наш не него нее нет ни
+них но ну о об однако он она они оно от очень по под при с со так также такой там те тем то того тоже той только
+том ты у уже хотя чего чей чем что чтобы чье чья эта эти это этот я вообще просто вот значит типа как-то музыка`),
+	"de": toSet(`aber alle allem allen aller alles als also am an ander andere anderem anderen anderer anderes auch auf
+aus bei bin bis bist da damit dann das dass dein deine dem den denn der des dich die dies diese diesem diesen dieser
+dieses dir doch dort du durch ein eine einem einen einer eines er es etwas euch euer für hab habe haben hat hatte
+hier hin ich ihm ihn ihnen ihr ihre im in ist ja jede jedem jeden jeder jedes kann kein keine mal man mein mich mir
+mit muss nach nicht nichts noch nun nur ob oder ohne schon sehr sein seine sich sie sind so solche soll über um und
+uns unser unter viel vom von vor war waren was weil wenn wer wie wir wird wo zu zum zur zwar musik`),
+	"fr": toSet(`à ai alors au aussi autre aux avec avoir bien c ça ce cela ces cet cette comme dans de des donc du elle
+elles en encore est et été être eu fait faire il ils j je la le les leur lui m ma mais me même mes moi mon ne nos
+notre nous on ou où par pas peu plus pour qu que quel quelle qui sa sans se ses si son sont sur ta te tes toi ton
+tous tout très tu un une vos votre vous y voilà euh musique`),
+	"es": toSet(`a al algo como con cual cuando de del desde donde el ella ellas ellos en entre era es esa ese eso esta
+este esto estos fue ha hay la las le les lo los más me mi muy nada ni no nos o os para pero poco por porque que quien
+se ser si sin sobre su sus también tan te ti tu tus un una uno unos usted vamos y ya yo bueno pues música`),
+	"pt": toSet(`a ao aos as até com como da das de dela dele do dos e ela ele eles em entre era essa esse esta este eu
+foi há isso isto já la lhe mais mas me mesmo meu minha muito na não nas nem no nos nós o os ou para pela pelo por
+qual quando que quem se sem seu sua também te tem tu um uma você vocês então né música`),
+	"it": toSet(`a ad al alla alle anche che chi ci come con da dal dalla dei del della delle di e è ed gli ha hanno
+i il in io la le lei lo loro lui ma mi mia mio ne nel nella no noi non o per perché più quale quando quello questo
+se si sono su sua suo ti tra tu un una uno voi allora cioè musica`),
+}
+
+// Stopwords returns stopwords of the language or empty set for unknown language.
+// Regional subtags are ignored: "en-GB" uses "en" list.
+func Stopwords(language string) map[string]bool {
+	language = strings.ToLower(language)
+	if i := strings.IndexAny(language, "-_"); i > 0 {
+		language = language[:i]
+	}
+
+	if set, ok := stopwords[language]; ok {
+		return set
+	}
+
+	return map[string]bool{}
+}
+
+func toSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+
+	return set
+}
@@ -0,0 +1,128 @@
+package keywords
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"time"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+	"transcribify/pkg/repository"
+)
+
+// Tagger extracts and stores keywords of the videos. In the background it recomputes IDF statistics
+// when the number of stored videos grows and tags videos stored without keywords.
+type Tagger struct {
+	keywords repository.Keyword
+	videos   repository.Video
+	logger   *zap.Logger
+
+	interval time.Duration
+	batch    int
+	growth   float64
+}
+
+func NewTagger(keywords repository.Keyword, videos repository.Video, logger *zap.Logger, conf config.KeywordsConfiguration) *Tagger {
+	return &Tagger{
+		keywords: keywords,
+		videos:   videos,
+		logger:   logger,
+		interval: conf.Interval,
+		batch:    conf.Batch,
+		growth:   conf.Growth,
+	}
+}
+
+// Start runs background refreshing until ctx is done
+func (t *Tagger) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+
+		for {
+			t.refresh(ctx)
+			t.backfill(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Keywords returns stored keywords of the video or extracts and stores them
+func (t *Tagger) Keywords(ctx context.Context, video *models.YTVideo, language string) ([]models.Keyword, error) {
+	keywords, err := t.keywords.GetKeywords(ctx, video.Id)
+	if err == nil {
+		return keywords, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	return t.tag(ctx, video, language)
+}
+
+func (t *Tagger) tag(ctx context.Context, video *models.YTVideo, language string) ([]models.Keyword, error) {
+	text := Transcript(video.Transcription)
+
+	documents, df, err := t.keywords.GetStats(ctx, language, Terms(text))
+	if err != nil {
+		return nil, err
+	}
+
+	keywords := Extract(text, language, Stats{Documents: documents, DF: df}, DefaultLimit)
+
+	if err = t.keywords.PutKeywords(ctx, video.Id, keywords); err != nil {
+		return nil, err
+	}
+
+	return keywords, nil
+}
+
+// refresh recomputes statistics if the number of videos grew by growth fraction
+func (t *Tagger) refresh(ctx context.Context) {
+	indexed, actual, err := t.keywords.CorpusSize(ctx)
+	if err != nil {
+		t.logger.Info("Failed to get keywords corpus size", zap.Error(err))
+		return
+	}
+
+	if actual == 0 || (indexed > 0 && float64(actual) < float64(indexed)*(1+t.growth)) {
+		return
+	}
+
+	documents, err := t.keywords.RefreshStats(ctx)
+	if err != nil {
+		t.logger.Info("Failed to refresh keywords statistics", zap.Error(err))
+		return
+	}
+
+	t.logger.Info("Keywords statistics refreshed", zap.Int("videos", documents), zap.Int("previous", indexed))
+}
+
+// backfill tags videos stored without keywords
+func (t *Tagger) backfill(ctx context.Context) {
+	requests, err := t.keywords.GetUntagged(ctx, t.batch)
+	if err != nil {
+		t.logger.Info("Failed to get untagged videos", zap.Error(err))
+		return
+	}
+
+	for _, request := range requests {
+		if ctx.Err() != nil {
+			return
+		}
+
+		video, err := t.videos.GetVideoByIDLang(ctx, request)
+		if err == nil {
+			_, err = t.tag(ctx, video, request.Language)
+		}
+		if err != nil {
+			t.logger.Info("Failed to tag video", zap.Any("video request", request), zap.Error(err))
+		}
+	}
+}
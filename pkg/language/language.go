@@ -0,0 +1,115 @@
+package language
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Reason explains why the language was served
+type Reason string
+
+const (
+	// Requested language is available
+	Requested Reason = "requested"
+	// Regional variant or base language of the requested one, e.g. `en-US` for `en`
+	Regional Reason = "regional-variant"
+	// Preference is the next language of the priority list
+	Preference Reason = "preference"
+	// Translated from another available language
+	Translated Reason = "translated"
+)
+
+// Preferred is a language tag with its quality
+type Preferred struct {
+	Tag string
+	Q   float64
+}
+
+// ParseAcceptLanguage parses Accept-Language header value and returns tags ordered by descending quality.
+// Tags with zero quality and wildcard are skipped, tags with equal quality keep their order.
+func ParseAcceptLanguage(header string) []string {
+	var preferred []Preferred
+
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		preferred = append(preferred, Preferred{Tag: tag, Q: q})
+	}
+
+	sort.SliceStable(preferred, func(i, j int) bool {
+		return preferred[i].Q > preferred[j].Q
+	})
+
+	tags := make([]string, 0, len(preferred))
+	for _, p := range preferred {
+		tags = append(tags, p.Tag)
+	}
+
+	return tags
+}
+
+// Base returns primary language subtag in lower case: `en` for `en-US`
+func Base(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i > 0 {
+		return tag[:i]
+	}
+
+	return tag
+}
+
+// Equal compares tags case-insensitively, `_` and `-` are equal separators
+func Equal(a, b string) bool {
+	return normalize(a) == normalize(b)
+}
+
+// Match returns the best available language for preferences in priority order.
+// For every preference exact match is tried first, then its regional variants and base language,
+// before moving to the next preference. The first preference is the requested language.
+func Match(preferences []string, available []string) (string, Reason, bool) {
+	for i, pref := range preferences {
+		for _, a := range available {
+			if Equal(pref, a) {
+				if i == 0 {
+					return a, Requested, true
+				}
+				return a, Preference, true
+			}
+		}
+
+		for _, a := range available {
+			if Base(pref) == Base(a) {
+				if i == 0 {
+					return a, Regional, true
+				}
+				return a, Preference, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+func normalize(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
@@ -0,0 +1,54 @@
+package language
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{name: "empty", header: "", want: []string{}},
+		{name: "single", header: "de", want: []string{"de"}},
+		{name: "ordered by quality", header: "en;q=0.5, fr-CH, de;q=0.9, fr;q=0.9", want: []string{"fr-CH", "de", "fr", "en"}},
+		{name: "wildcard and zero quality", header: "*;q=0.1, ru;q=0, uk", want: []string{"uk"}},
+		{name: "invalid quality", header: "es;q=abc, pt;q=0.2", want: []string{"es", "pt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences []string
+		available   []string
+		want        string
+		reason      Reason
+		ok          bool
+	}{
+		{name: "requested", preferences: []string{"en", "de"}, available: []string{"de", "en"}, want: "en", reason: Requested, ok: true},
+		{name: "regional variant", preferences: []string{"en", "de"}, available: []string{"de", "en-US"}, want: "en-US", reason: Regional, ok: true},
+		{name: "base of regional", preferences: []string{"pt-BR"}, available: []string{"pt"}, want: "pt", reason: Regional, ok: true},
+		{name: "case and separator", preferences: []string{"zh_hans"}, available: []string{"zh-Hans"}, want: "zh-Hans", reason: Requested, ok: true},
+		{name: "preference before its variant", preferences: []string{"fr", "de-AT", "de"}, available: []string{"de", "de-AT"}, want: "de-AT", reason: Preference, ok: true},
+		{name: "preference variant", preferences: []string{"fr", "de-AT"}, available: []string{"it", "de"}, want: "de", reason: Preference, ok: true},
+		{name: "no match", preferences: []string{"fr", "es"}, available: []string{"en"}, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason, ok := Match(tt.preferences, tt.available)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultBaseURL of OpenAI API
+const DefaultBaseURL = "https://api.openai.com/v1"
+
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+var ErrEmptyResponse = errors.New("model returned no choices")
+
+type (
+	Message struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+
+	// Chat represents chat completion model backend.
+	Chat interface {
+		Complete(ctx context.Context, messages []Message) (string, error)
+		// Model uses as a part of cache keys
+		Model() string
+	}
+
+	// OpenAI implements Chat for any OpenAI-compatible `/chat/completions` API
+	OpenAI struct {
+		client  *http.Client
+		baseURL string
+		apiKey  string
+		model   string
+	}
+
+	// APIError is returned for non 2xx responses
+	APIError struct {
+		Status  int
+		Message string
+	}
+)
+
+func NewOpenAI(client *http.Client, baseURL, apiKey, model string) *OpenAI {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &OpenAI{
+		client:  client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+	}
+}
+
+func (o *OpenAI) Model() string {
+	return o.model
+}
+
+type (
+	completionRequest struct {
+		Model    string    `json:"model"`
+		Messages []Message `json:"messages"`
+	}
+
+	completionResponse struct {
+		Choices []struct {
+			Message Message `json:"message"`
+		} `json:"choices"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+)
+
+func (o *OpenAI) Complete(ctx context.Context, messages []Message) (string, error) {
+	body, err := json.Marshal(completionRequest{Model: o.model, Messages: messages})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+
+	response, err := o.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(response.Body, 4<<20))
+	if err != nil {
+		return "", err
+	}
+
+	var completion completionResponse
+	decodeErr := json.Unmarshal(raw, &completion)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		apiErr := &APIError{Status: response.StatusCode, Message: string(raw)}
+		if decodeErr == nil && completion.Error != nil {
+			apiErr.Message = completion.Error.Message
+		}
+
+		return "", apiErr
+	}
+
+	if decodeErr != nil {
+		return "", decodeErr
+	}
+
+	if len(completion.Choices) == 0 {
+		return "", ErrEmptyResponse
+	}
+
+	return strings.TrimSpace(completion.Choices[0].Message.Content), nil
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("model API status %d: %s", e.Status, e.Message)
+}
@@ -0,0 +1,141 @@
+package playlist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultBaseURL of YouTube Data API
+const DefaultBaseURL = "https://www.googleapis.com/youtube/v3"
+
+var ErrNotConfigured = errors.New("playlist resolver is not configured")
+
+type (
+	// Resolver returns up to limit ids of videos in the playlist starting from offset.
+	Resolver interface {
+		Resolve(ctx context.Context, playlistID string, offset, limit int) (*Page, error)
+	}
+
+	// Page of the playlist videos
+	Page struct {
+		IDs []string
+		// Total is the number of videos in the playlist
+		Total int
+	}
+
+	// DataAPIResolver uses YouTube Data API playlistItems endpoint
+	DataAPIResolver struct {
+		client  *http.Client
+		baseURL string
+		key     string
+	}
+
+	// NoopResolver is used when no API key is configured
+	NoopResolver struct{}
+)
+
+func NewDataAPIResolver(client *http.Client, baseURL, key string) *DataAPIResolver {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &DataAPIResolver{
+		client:  client,
+		baseURL: baseURL,
+		key:     key,
+	}
+}
+
+func (d *DataAPIResolver) Resolve(ctx context.Context, playlistID string, offset, limit int) (*Page, error) {
+	var (
+		result = &Page{IDs: make([]string, 0, limit)}
+		seen   int
+		token  string
+	)
+
+	for {
+		page, err := d.page(ctx, playlistID, token)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Items {
+			if seen >= offset && len(result.IDs) < limit {
+				result.IDs = append(result.IDs, item.ContentDetails.VideoID)
+			}
+			seen++
+		}
+
+		token = page.NextPageToken
+		if token == "" {
+			// the whole playlist is read, so the number of videos is exact
+			result.Total = seen
+
+			return result, nil
+		}
+
+		if len(result.IDs) >= limit {
+			result.Total = page.PageInfo.TotalResults
+			// totalResults is an estimate, but there is at least one more video
+			if result.Total <= seen {
+				result.Total = seen + 1
+			}
+
+			return result, nil
+		}
+	}
+}
+
+type itemsPage struct {
+	NextPageToken string `json:"nextPageToken"`
+	PageInfo      struct {
+		TotalResults int `json:"totalResults"`
+	} `json:"pageInfo"`
+	Items []struct {
+		ContentDetails struct {
+			VideoID string `json:"videoId"`
+		} `json:"contentDetails"`
+	} `json:"items"`
+}
+
+func (d *DataAPIResolver) page(ctx context.Context, playlistID, token string) (*itemsPage, error) {
+	query := url.Values{}
+	query.Set("part", "contentDetails")
+	query.Set("maxResults", strconv.Itoa(50))
+	query.Set("playlistId", playlistID)
+	query.Set("key", d.key)
+	if token != "" {
+		query.Set("pageToken", token)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.baseURL+"/playlistItems?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("playlist %s: unexpected status %s", playlistID, response.Status)
+	}
+
+	page := new(itemsPage)
+	if err = json.NewDecoder(response.Body).Decode(page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (NoopResolver) Resolve(context.Context, string, int, int) (*Page, error) {
+	return nil, ErrNotConfigured
+}
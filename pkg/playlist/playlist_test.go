@@ -0,0 +1,77 @@
+package playlist
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDataAPIResolver_Resolve(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "/playlistItems", r.URL.Path)
+		assert.Equal(t, "key", r.URL.Query().Get("key"))
+
+		switch r.URL.Query().Get("pageToken") {
+		case "":
+			fmt.Fprint(w, `{"nextPageToken":"next","pageInfo":{"totalResults":3},`+
+				`"items":[{"contentDetails":{"videoId":"00000000001"}},{"contentDetails":{"videoId":"00000000002"}}]}`)
+		case "next":
+			fmt.Fprint(w, `{"pageInfo":{"totalResults":3},"items":[{"contentDetails":{"videoId":"00000000003"}}]}`)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewDataAPIResolver(server.Client(), server.URL, "key")
+
+	tests := []struct {
+		name     string
+		offset   int
+		limit    int
+		expected *Page
+		requests int32
+	}{
+		{
+			name:     "Whole playlist",
+			limit:    10,
+			expected: &Page{IDs: []string{"00000000001", "00000000002", "00000000003"}, Total: 3},
+			requests: 2,
+		},
+		{
+			name:     "First page",
+			limit:    1,
+			expected: &Page{IDs: []string{"00000000001"}, Total: 3},
+			requests: 1,
+		},
+		{
+			name:     "Offset across pages",
+			offset:   1,
+			limit:    2,
+			expected: &Page{IDs: []string{"00000000002", "00000000003"}, Total: 3},
+			requests: 2,
+		},
+		{
+			name:     "Without limit",
+			offset:   1,
+			expected: &Page{IDs: []string{}, Total: 3},
+			requests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&requests, 0)
+
+			page, err := resolver.Resolve(context.Background(), "PL590L5WQmH8fJ54F369BLDSqIwcs-TCfs", tt.offset, tt.limit)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, page)
+			assert.Equal(t, tt.requests, atomic.LoadInt32(&requests))
+		})
+	}
+}
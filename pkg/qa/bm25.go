@@ -0,0 +1,195 @@
+package qa
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"transcribify/internal/models"
+	"unicode"
+)
+
+const (
+	// K1 controls term frequency saturation
+	K1 = 1.2
+	// B controls document length normalization
+	B = 0.75
+)
+
+// Window is a passage of consecutive transcription segments
+type Window struct {
+	FirstSegment int
+	LastSegment  int
+	Start        float64
+	End          float64
+	Text         string
+}
+
+// Passage is a window with its relevance score
+type Passage struct {
+	Window
+	Score float64
+}
+
+// Windows groups non-empty segments into windows of size segments. Every window starts stride segments
+// after the previous one, so stride less than size makes overlapping windows.
+func Windows(transcription []models.Transcription, size, stride int) []Window {
+	if size < 1 {
+		size = 1
+	}
+	if stride < 1 || stride > size {
+		stride = size
+	}
+
+	indexes := make([]int, 0, len(transcription))
+	for i, t := range transcription {
+		if strings.TrimSpace(t.Subtitle) != "" {
+			indexes = append(indexes, i)
+		}
+	}
+
+	var windows []Window
+	for start := 0; start < len(indexes); start += stride {
+		end := start + size
+		if end > len(indexes) {
+			end = len(indexes)
+		}
+
+		parts := make([]string, 0, end-start)
+		window := Window{
+			FirstSegment: indexes[start],
+			LastSegment:  indexes[end-1],
+			Start:        transcription[indexes[start]].Start,
+		}
+		for _, i := range indexes[start:end] {
+			parts = append(parts, strings.TrimSpace(transcription[i].Subtitle))
+			window.End = math.Max(window.End, transcription[i].Start+transcription[i].Dur)
+		}
+		window.Text = strings.Join(parts, " ")
+
+		windows = append(windows, window)
+
+		if end == len(indexes) {
+			break
+		}
+	}
+
+	return windows
+}
+
+// BM25 is Okapi BM25 index over in-memory documents
+type BM25 struct {
+	docs   []map[string]int
+	length []int
+	avg    float64
+	df     map[string]int
+}
+
+func NewBM25(documents []string) *BM25 {
+	index := &BM25{
+		docs:   make([]map[string]int, len(documents)),
+		length: make([]int, len(documents)),
+		df:     make(map[string]int),
+	}
+
+	var total int
+	for i, d := range documents {
+		terms := Tokenize(d)
+		index.docs[i] = make(map[string]int, len(terms))
+		for _, t := range terms {
+			index.docs[i][t]++
+		}
+		for t := range index.docs[i] {
+			index.df[t]++
+		}
+		index.length[i] = len(terms)
+		total += len(terms)
+	}
+
+	if len(documents) > 0 {
+		index.avg = float64(total) / float64(len(documents))
+	}
+
+	return index
+}
+
+// Score returns relevance of every document to the query
+func (index *BM25) Score(query string) []float64 {
+	var (
+		scores = make([]float64, len(index.docs))
+		n      = float64(len(index.docs))
+		seen   = make(map[string]bool)
+	)
+
+	for _, term := range Tokenize(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		df := float64(index.df[term])
+		if df == 0 {
+			continue
+		}
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+		for i, doc := range index.docs {
+			tf := float64(doc[term])
+			if tf == 0 {
+				continue
+			}
+			norm := 1 - B + B*float64(index.length[i])/index.avg
+			scores[i] += idf * tf * (K1 + 1) / (tf + K1*norm)
+		}
+	}
+
+	return scores
+}
+
+// Retrieve returns at most limit windows relevant to the query ordered by descending score.
+// Windows without query terms and windows overlapping more relevant ones are skipped.
+func Retrieve(windows []Window, query string, limit int) []Passage {
+	documents := make([]string, len(windows))
+	for i, w := range windows {
+		documents[i] = w.Text
+	}
+
+	scores := NewBM25(documents).Score(query)
+
+	candidates := make([]Passage, 0, len(windows))
+	for i, w := range windows {
+		if scores[i] > 0 {
+			candidates = append(candidates, Passage{Window: w, Score: scores[i]})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	passages := make([]Passage, 0, limit)
+	for _, c := range candidates {
+		if limit > 0 && len(passages) == limit {
+			break
+		}
+
+		overlaps := false
+		for _, p := range passages {
+			if c.FirstSegment <= p.LastSegment && p.FirstSegment <= c.LastSegment {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			passages = append(passages, c)
+		}
+	}
+
+	return passages
+}
+
+// Tokenize lowercases text and splits it into words
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,156 @@
+package qa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"transcribify/internal/models"
+	"transcribify/pkg/captions"
+	"transcribify/pkg/llm"
+)
+
+// Mode of answering the question
+type Mode string
+
+const (
+	// Answer sends retrieved passages to the model
+	Answer Mode = "answer"
+	// Retrieval returns retrieved passages only and works without model
+	Retrieval Mode = "retrieval"
+)
+
+const (
+	// DefaultLimit of passages sent to the model
+	DefaultLimit = 5
+	// MaxLimit of passages
+	MaxLimit = 20
+	// WindowSize is the number of segments in passage
+	WindowSize = 6
+	// WindowStride is the number of segments between starts of consecutive passages
+	WindowStride = 3
+)
+
+var (
+	ErrEmptyQuestion = errors.New("empty question")
+	ErrUnknownMode   = errors.New("unknown answer mode")
+	ErrNotConfigured = errors.New("question answering model is not configured")
+	citation         = regexp.MustCompile(`\[(\d+(?:\s*,\s*\d+)*)]`)
+)
+
+// Result of the question. Cited are indexes of Passages referenced by the answer.
+type Result struct {
+	Answer   string
+	Model    string
+	Passages []Passage
+	Cited    []int
+}
+
+// Answerer retrieves transcript passages relevant to the question with BM25
+// and asks chat model to answer using only these passages.
+type Answerer struct {
+	chat llm.Chat
+}
+
+// New accepts nil chat, then only Retrieval mode is available
+func New(chat llm.Chat) *Answerer {
+	return &Answerer{chat: chat}
+}
+
+// ParseMode returns Answer for empty mode if model is configured, otherwise Retrieval
+func (a *Answerer) ParseMode(mode string) (Mode, error) {
+	switch Mode(mode) {
+	case "":
+		if a.chat == nil {
+			return Retrieval, nil
+		}
+		return Answer, nil
+	case Answer:
+		if a.chat == nil {
+			return "", ErrNotConfigured
+		}
+		return Answer, nil
+	case Retrieval:
+		return Retrieval, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownMode, mode)
+	}
+}
+
+func (a *Answerer) Ask(ctx context.Context, video *models.YTVideo, question string, mode Mode, limit int) (*Result, error) {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return nil, ErrEmptyQuestion
+	}
+
+	if limit < 1 || limit > MaxLimit {
+		limit = DefaultLimit
+	}
+
+	result := &Result{
+		Passages: Retrieve(Windows(video.Transcription, WindowSize, WindowStride), question, limit),
+		Cited:    []int{},
+	}
+
+	if mode == Retrieval || len(result.Passages) == 0 {
+		return result, nil
+	}
+	if a.chat == nil {
+		return nil, ErrNotConfigured
+	}
+
+	answer, err := a.chat.Complete(ctx, Prompt(video.Title, question, result.Passages))
+	if err != nil {
+		return nil, err
+	}
+
+	result.Answer = answer
+	result.Model = a.chat.Model()
+	result.Cited = Citations(answer, len(result.Passages))
+
+	return result, nil
+}
+
+// Prompt numbers passages from 1, so the model can cite them as [n]
+func Prompt(title, question string, passages []Passage) []llm.Message {
+	var excerpts strings.Builder
+	for i, p := range passages {
+		fmt.Fprintf(&excerpts, "[%d] (%s) %s\n", i+1, captions.Clock(p.Start), p.Text)
+	}
+
+	return []llm.Message{
+		{
+			Role: llm.RoleSystem,
+			Content: "You answer questions about a YouTube video using only the numbered transcript excerpts. " +
+				"Cite every excerpt you used as [n]. " +
+				"If the excerpts don`t contain the answer, say so. Answer in the language of the question.",
+		},
+		{
+			Role:    llm.RoleUser,
+			Content: fmt.Sprintf("Video title: %s\n\nExcerpts:\n%s\nQuestion: %s", title, excerpts.String(), question),
+		},
+	}
+}
+
+// Citations returns zero-based indexes of passages cited in answer as [n] or [n, m] in order of appearance
+func Citations(answer string, passages int) []int {
+	var (
+		cited = []int{}
+		seen  = make(map[int]bool)
+	)
+
+	for _, m := range citation.FindAllStringSubmatch(answer, -1) {
+		for _, n := range strings.Split(m[1], ",") {
+			i, err := strconv.Atoi(strings.TrimSpace(n))
+			if err != nil || i < 1 || i > passages || seen[i-1] {
+				continue
+			}
+			seen[i-1] = true
+			cited = append(cited, i-1)
+		}
+	}
+
+	return cited
+}
@@ -0,0 +1,107 @@
+package qa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"transcribify/internal/models"
+	"transcribify/pkg/llm"
+)
+
+func transcription(subtitles ...string) []models.Transcription {
+	res := make([]models.Transcription, len(subtitles))
+	for i, s := range subtitles {
+		res[i] = models.Transcription{Subtitle: s, Start: float64(i * 10), Dur: 10}
+	}
+
+	return res
+}
+
+func TestWindows(t *testing.T) {
+	windows := Windows(transcription("a", "", "b", "c", "d"), 2, 1)
+
+	assert.Equal(t, []Window{
+		{FirstSegment: 0, LastSegment: 2, Start: 0, End: 30, Text: "a b"},
+		{FirstSegment: 2, LastSegment: 3, Start: 20, End: 40, Text: "b c"},
+		{FirstSegment: 3, LastSegment: 4, Start: 30, End: 50, Text: "c d"},
+	}, windows)
+}
+
+func TestRetrieve(t *testing.T) {
+	windows := Windows(transcription(
+		"welcome to the show",
+		"today we talk about pricing",
+		"the pricing starts at ten dollars",
+		"thanks for watching the show",
+	), 1, 1)
+
+	passages := Retrieve(windows, "When did they mention pricing?", 5)
+
+	assert.Len(t, passages, 2)
+	assert.Equal(t, 1, passages[0].FirstSegment)
+	assert.Equal(t, 2, passages[1].FirstSegment)
+	assert.Greater(t, passages[0].Score, passages[1].Score)
+
+	overlapping := Retrieve(Windows(transcription("pricing", "pricing", "other"), 2, 1), "pricing", 5)
+	assert.Len(t, overlapping, 1)
+}
+
+func TestCitations(t *testing.T) {
+	assert.Equal(t, []int{1, 0, 2}, Citations("At 0:10 [2]. Also [1, 3] and [2] and [9].", 3))
+	assert.Equal(t, []int{}, Citations("no citations", 3))
+}
+
+func TestAnswerer_Ask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []llm.Message `json:"messages"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Contains(t, req.Messages[1].Content, "[1] (00:00) welcome to the show today we talk about pricing")
+		assert.True(t, strings.HasSuffix(req.Messages[1].Content, "Question: pricing?"))
+
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"They mention pricing at 0:10 [1]."}}]}`)
+	}))
+	defer server.Close()
+
+	video := &models.YTVideo{Transcription: transcription(
+		"welcome to the show",
+		"today we talk about pricing",
+	)}
+
+	answerer := New(llm.NewOpenAI(server.Client(), server.URL, "", "stub"))
+	mode, err := answerer.ParseMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, Answer, mode)
+
+	result, err := answerer.Ask(context.Background(), video, "pricing?", mode, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "They mention pricing at 0:10 [1].", result.Answer)
+	assert.Equal(t, "stub", result.Model)
+	assert.Equal(t, []int{0}, result.Cited)
+
+	_, err = answerer.Ask(context.Background(), video, " ", mode, 0)
+	assert.ErrorIs(t, err, ErrEmptyQuestion)
+}
+
+func TestAnswerer_Retrieval(t *testing.T) {
+	answerer := New(nil)
+
+	mode, err := answerer.ParseMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, Retrieval, mode)
+
+	_, err = answerer.ParseMode("answer")
+	assert.ErrorIs(t, err, ErrNotConfigured)
+
+	result, err := answerer.Ask(context.Background(),
+		&models.YTVideo{Transcription: transcription("pricing")}, "pricing", mode, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Answer)
+	assert.Len(t, result.Passages, 1)
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"transcribify/internal/models"
+)
+
+type EmbeddingRepository struct {
+	client *pgxpool.Pool
+}
+
+func NewEmbeddingRepository(client *pgxpool.Pool) *EmbeddingRepository {
+	return &EmbeddingRepository{client: client}
+}
+
+func (e *EmbeddingRepository) GetEmbeddings(ctx context.Context, embedder string, afterID int, limit int) ([]models.Embedding, error) {
+	var (
+		rawQuery = `SELECT e.id, e.video_id, v.video_id, v.language, v.title, e.window_index, e.start, e.first_segment, e.text, e.vector
+					FROM video_embeddings e
+					JOIN video v on v.id = e.video_id
+					WHERE e.embedder = $1 and e.id > $2
+					ORDER BY e.id
+					LIMIT $3`
+		query = formatQuery(rawQuery)
+	)
+
+	rows, err := e.client.Query(ctx, query, embedder, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var embeddings []models.Embedding
+	for rows.Next() {
+		var em models.Embedding
+		err = rows.Scan(&em.ID, &em.VideoID, &em.YouTubeID, &em.Language, &em.Title,
+			&em.Window, &em.Start, &em.FirstSegment, &em.Text, &em.Vector)
+		if err != nil {
+			return nil, err
+		}
+		embeddings = append(embeddings, em)
+	}
+
+	return embeddings, rows.Err()
+}
+
+func (e *EmbeddingRepository) PutEmbeddings(ctx context.Context, embedder string, videoID int, embeddings []models.Embedding) error {
+	tx, err := e.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	_, err = tx.Exec(ctx, "delete from video_embeddings where video_id = $1 and embedder = $2", videoID, embedder)
+	if err != nil {
+		return err
+	}
+
+	for i := range embeddings {
+		em := &embeddings[i]
+		err = tx.QueryRow(ctx,
+			`insert into video_embeddings (video_id, embedder, window_index, start, first_segment, text, vector)
+			values ($1, $2, $3, $4, $5, $6, $7) returning id`,
+			videoID, embedder, em.Window, em.Start, em.FirstSegment, em.Text, em.Vector,
+		).Scan(&em.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(ctx,
+		`insert into embedded_videos (video_id, embedder) values ($1, $2)
+		on conflict (video_id, embedder) do update set updated_at = now()`, videoID, embedder)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (e *EmbeddingRepository) GetUnembedded(ctx context.Context, embedder string, limit int) ([]models.VideoRequest, error) {
+	rows, err := e.client.Query(ctx,
+		`select v.video_id, v.language from video v
+		where not v.machine_translated and v.source = 'youtube' and
+		      not exists (select 1 from embedded_videos ev where ev.video_id = v.id and ev.embedder = $1)
+		order by v.id limit $2`, embedder, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []models.VideoRequest
+	for rows.Next() {
+		var request models.VideoRequest
+		if err = rows.Scan(&request.VideoID, &request.Language); err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, rows.Err()
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"transcribify/internal/models"
+)
+
+// ImportRepository stores transcripts uploaded by users in video table with `import` source
+type ImportRepository struct {
+	client *pgxpool.Pool
+}
+
+func NewImportRepository(client *pgxpool.Pool) *ImportRepository {
+	return &ImportRepository{client: client}
+}
+
+func (i *ImportRepository) CreateImport(ctx context.Context, uid int, request models.VideoRequest, video *models.YTVideo) (int, error) {
+	var (
+		rawQuery = `INSERT INTO video (title, description, available_langs, length_in_seconds, thumbnails, transcription,
+					                   video_id, language, source, owner)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'import', $9)
+					ON CONFLICT (owner, video_id, language) WHERE source = 'import'
+					DO UPDATE SET title = excluded.title, description = excluded.description,
+					              length_in_seconds = excluded.length_in_seconds, transcription = excluded.transcription
+					RETURNING id`
+		query = formatQuery(rawQuery)
+		id    int
+	)
+
+	rawThumb, err := json.Marshal(video.Thumbnails)
+	if err != nil {
+		return -2, err
+	}
+	rawTransc, err := json.Marshal(video.Transcription)
+	if err != nil {
+		return -2, err
+	}
+
+	err = i.client.QueryRow(ctx, query,
+		video.Title, video.Description, video.AvailableLangs, video.LengthInSeconds, rawThumb, rawTransc,
+		request.VideoID, request.Language, uid,
+	).Scan(&id)
+	if err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+// GetImport lists all languages imported by the user as AvailableLangs
+func (i *ImportRepository) GetImport(ctx context.Context, uid int, request models.VideoRequest) (*models.YTVideo, error) {
+	var (
+		rawQuery = `SELECT id, title, description, length_in_seconds, thumbnails, transcription,
+					       array(select language from video i
+					             where i.owner = vd.owner and i.video_id = vd.video_id and i.source = 'import' order by i.id)
+					FROM video as vd
+					WHERE vd.owner = $1 and vd.video_id = $2 and vd.language = $3 and vd.source = 'import'`
+		query    = formatQuery(rawQuery)
+		rawThumb json.RawMessage
+		rawTrans json.RawMessage
+		video    = models.YTVideo{Source: models.SourceImport}
+	)
+
+	err := i.client.QueryRow(ctx, query, uid, request.VideoID, request.Language).
+		Scan(&video.Id, &video.Title, &video.Description, &video.LengthInSeconds, &rawThumb, &rawTrans, &video.AvailableLangs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(rawThumb, &video.Thumbnails); err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(rawTrans, &video.Transcription); err != nil {
+		return nil, err
+	}
+
+	return &video, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"transcribify/internal/models"
+)
+
+const jobColumns = `id, user_id, video_id, language, state, coalesce(error, ''), coalesce(result_id, -1), attempts, created_at, updated_at`
+
+type JobRepository struct {
+	client *pgxpool.Pool
+}
+
+func NewJobRepository(client *pgxpool.Pool) *JobRepository {
+	return &JobRepository{client: client}
+}
+
+func (j *JobRepository) CreateJob(ctx context.Context, uid int, request models.VideoRequest) (*models.Job, error) {
+	query := "insert into jobs (user_id, video_id, language) values ($1, $2, $3) returning " + jobColumns
+
+	return scanJob(j.client.QueryRow(ctx, query, uid, request.VideoID, request.Language))
+}
+
+func (j *JobRepository) GetJob(ctx context.Context, id int) (*models.Job, error) {
+	query := "select " + jobColumns + " from jobs where id = $1"
+
+	return scanJob(j.client.QueryRow(ctx, query, id))
+}
+
+func (j *JobRepository) ClaimJob(ctx context.Context) (*models.Job, error) {
+	query := "select " + jobColumns + " from claim_job()"
+
+	return scanJob(j.client.QueryRow(ctx, query))
+}
+
+func (j *JobRepository) FinishJob(ctx context.Context, id int, resultID int, jobErr error) error {
+	var (
+		rawQuery = `update jobs
+					set state = $2, result_id = nullif($3, -1), error = nullif($4, ''), updated_at = now()
+					where id = $1`
+		query = formatQuery(rawQuery)
+		state = models.JobSucceeded
+		msg   string
+	)
+
+	if jobErr != nil {
+		state = models.JobFailed
+		msg = jobErr.Error()
+	}
+
+	_, err := j.client.Exec(ctx, query, id, state, resultID, msg)
+	return err
+}
+
+func (j *JobRepository) RequeueRunning(ctx context.Context) (int64, error) {
+	tag, err := j.client.Exec(ctx, "update jobs set state = 'queued', updated_at = now() where state = 'running'")
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func scanJob(row pgx.Row) (*models.Job, error) {
+	job := new(models.Job)
+
+	err := row.Scan(&job.ID, &job.UserID, &job.VideoID, &job.Language, &job.State,
+		&job.Error, &job.ResultID, &job.Attempts, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
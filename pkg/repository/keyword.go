@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"transcribify/internal/models"
+)
+
+type KeywordRepository struct {
+	client *pgxpool.Pool
+}
+
+func NewKeywordRepository(client *pgxpool.Pool) *KeywordRepository {
+	return &KeywordRepository{client: client}
+}
+
+func (k *KeywordRepository) GetKeywords(ctx context.Context, videoID int) ([]models.Keyword, error) {
+	var tagged bool
+
+	err := k.client.QueryRow(ctx, "select keywords_updated_at is not null from video where id = $1", videoID).Scan(&tagged)
+	if err != nil {
+		return nil, err
+	}
+	if !tagged {
+		return nil, pgx.ErrNoRows
+	}
+
+	rows, err := k.client.Query(ctx,
+		"select keyword, score from video_keywords where video_id = $1 order by score desc, keyword", videoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keywords := make([]models.Keyword, 0)
+	for rows.Next() {
+		var keyword models.Keyword
+		if err = rows.Scan(&keyword.Keyword, &keyword.Score); err != nil {
+			return nil, err
+		}
+		keywords = append(keywords, keyword)
+	}
+
+	return keywords, rows.Err()
+}
+
+func (k *KeywordRepository) PutKeywords(ctx context.Context, videoID int, keywords []models.Keyword) error {
+	tx, err := k.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err = tx.Exec(ctx, "delete from video_keywords where video_id = $1", videoID); err != nil {
+		return err
+	}
+
+	for _, keyword := range keywords {
+		_, err = tx.Exec(ctx, "insert into video_keywords (video_id, keyword, score) values ($1, $2, $3)",
+			videoID, keyword.Keyword, keyword.Score)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err = tx.Exec(ctx, "update video set keywords_updated_at = now() where id = $1", videoID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (k *KeywordRepository) GetStats(ctx context.Context, language string, terms []string) (int, map[string]int, error) {
+	var (
+		documents int
+		df        = make(map[string]int, len(terms))
+	)
+
+	err := k.client.QueryRow(ctx,
+		"select coalesce((select documents from keyword_corpus where language = $1), 0)", language).Scan(&documents)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	rows, err := k.client.Query(ctx,
+		"select term, df from keyword_stats where language = $1 and term = any($2)", language, terms)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			term string
+			n    int
+		)
+		if err = rows.Scan(&term, &n); err != nil {
+			return 0, nil, err
+		}
+		df[term] = n
+	}
+
+	return documents, df, rows.Err()
+}
+
+func (k *KeywordRepository) CorpusSize(ctx context.Context) (int, int, error) {
+	var indexed, actual int
+
+	err := k.client.QueryRow(ctx,
+		"select coalesce((select sum(documents) from keyword_corpus), 0), (select count(*) from video where not machine_translated and source = 'youtube')").
+		Scan(&indexed, &actual)
+
+	return indexed, actual, err
+}
+
+func (k *KeywordRepository) RefreshStats(ctx context.Context) (int, error) {
+	var documents int
+
+	err := k.client.QueryRow(ctx, "select refresh_keyword_stats()").Scan(&documents)
+
+	return documents, err
+}
+
+func (k *KeywordRepository) GetUntagged(ctx context.Context, limit int) ([]models.VideoRequest, error) {
+	rows, err := k.client.Query(ctx,
+		"select video_id, language from video where keywords_updated_at is null and not machine_translated and source = 'youtube' order by id limit $1", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []models.VideoRequest
+	for rows.Next() {
+		var request models.VideoRequest
+		if err = rows.Scan(&request.VideoID, &request.Language); err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, rows.Err()
+}
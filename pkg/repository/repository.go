@@ -2,15 +2,24 @@ package repository
 
 import (
 	"context"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
 	"transcribify/internal/models"
 	"transcribify/pkg/hash"
 )
 
 type (
 	Repository struct {
-		Video Video
-		User  User
+		Video       Video
+		User        User
+		Search      Search
+		Job         Job
+		Webhook     Webhook
+		Summary     Summary
+		Keyword     Keyword
+		Embedding   Embedding
+		Translation Translation
+		Import      Import
 	}
 
 	Video interface {
@@ -18,6 +27,9 @@ type (
 
 		GetVideoByIDLang(context.Context, models.VideoRequest) (*models.YTVideo, error)
 
+		// SetChapters stores chapters alongside the video row with id.
+		SetChapters(ctx context.Context, id int, source string, chapters []models.Chapter) error
+
 		Update(context.Context, models.VideoRequest, *models.YTVideo) error
 		Remove(context.Context, models.VideoRequest) error
 	}
@@ -27,18 +39,139 @@ type (
 		// GetUserByLogin use models.User Email and Password fields to fill model.User struct.
 		GetUserByLogin(ctx context.Context, user *models.User) error
 
-		GetUserVideos(ctx context.Context, uid int, limit int, offset int) (map[int]models.YTVideo, error)
+		// GetUserVideos filters videos by keyword if it isn`t empty.
+		GetUserVideos(ctx context.Context, uid int, limit int, offset int, keyword string) (map[int]models.YTVideo, error)
 
 		// PutUser store user. If user exist fill models.User ID field.
 		PutUser(ctx context.Context, user *models.User) error
 
 		PutUserVideo(ctx context.Context, uid int, vidID int) error
 	}
+
+	Search interface {
+
+		// Search runs full-text search over all stored transcriptions.
+		Search(ctx context.Context, request models.SearchRequest) ([]models.SearchResult, error)
+	}
+
+	Job interface {
+		CreateJob(ctx context.Context, uid int, request models.VideoRequest) (*models.Job, error)
+
+		GetJob(ctx context.Context, id int) (*models.Job, error)
+
+		// ClaimJob marks the oldest queued job as running. Returns pgx.ErrNoRows if there is no queued jobs.
+		ClaimJob(ctx context.Context) (*models.Job, error)
+
+		// FinishJob marks job as succeeded with stored video id or as failed if jobErr not nil.
+		FinishJob(ctx context.Context, id int, resultID int, jobErr error) error
+
+		// RequeueRunning returns jobs interrupted by restart to the queue.
+		RequeueRunning(ctx context.Context) (int64, error)
+	}
+
+	Webhook interface {
+
+		// CreateWebhook fills models.Webhook ID and CreatedAt fields.
+		CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+
+		GetWebhook(ctx context.Context, id int) (*models.Webhook, error)
+
+		GetWebhooks(ctx context.Context, uid int) ([]models.Webhook, error)
+
+		// GetWebhooksByEvent returns user webhooks subscribed to event.
+		GetWebhooksByEvent(ctx context.Context, uid int, event string) ([]models.Webhook, error)
+
+		// DeleteWebhook returns pgx.ErrNoRows if user has no such webhook.
+		DeleteWebhook(ctx context.Context, uid int, id int) error
+
+		// CreateDelivery stores pending delivery and fills the rest of its fields.
+		CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+
+		// UpdateDelivery stores State, Attempts, ResponseStatus and Error fields.
+		UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+
+		GetDelivery(ctx context.Context, id int) (*models.WebhookDelivery, error)
+
+		// GetDeliveries returns the latest deliveries first.
+		GetDeliveries(ctx context.Context, webhookID int, limit int, offset int) ([]models.WebhookDelivery, error)
+
+		// ClaimStaleDeliveries returns up to limit pending deliveries not updated for staleAfter.
+		// Their UpdatedAt is set to now, so concurrent sweeps don`t claim them again.
+		ClaimStaleDeliveries(ctx context.Context, staleAfter time.Duration, limit int) ([]models.WebhookDelivery, error)
+	}
+
+	Summary interface {
+
+		// GetSummary returns pgx.ErrNoRows if summary isn`t cached.
+		GetSummary(ctx context.Context, request models.VideoRequest, style, model string) (*models.Summary, error)
+
+		// PutSummary stores or replaces summary and fills models.Summary CreatedAt field.
+		PutSummary(ctx context.Context, summary *models.Summary) error
+	}
+
+	Keyword interface {
+
+		// GetKeywords returns pgx.ErrNoRows if keywords of the video weren`t extracted yet.
+		GetKeywords(ctx context.Context, videoID int) ([]models.Keyword, error)
+
+		// PutKeywords replaces keywords of the video and marks it as tagged.
+		PutKeywords(ctx context.Context, videoID int, keywords []models.Keyword) error
+
+		// GetStats returns number of videos of the language and document frequency of the terms.
+		GetStats(ctx context.Context, language string, terms []string) (int, map[string]int, error)
+
+		// CorpusSize returns number of videos in the last statistics and current number of videos.
+		CorpusSize(ctx context.Context) (indexed int, actual int, err error)
+
+		// RefreshStats recomputes terms statistics and returns number of indexed videos.
+		RefreshStats(ctx context.Context) (int, error)
+
+		// GetUntagged returns oldest videos without extracted keywords.
+		GetUntagged(ctx context.Context, limit int) ([]models.VideoRequest, error)
+	}
+
+	Translation interface {
+
+		// GetTranslation returns pgx.ErrNoRows if the video wasn`t translated from the language by the translator.
+		GetTranslation(ctx context.Context, request models.VideoRequest, from, translator string) (*models.YTVideo, error)
+
+		// PutTranslation stores or replaces machine translated video and returns its id.
+		PutTranslation(ctx context.Context, request models.VideoRequest, from, translator string, video *models.YTVideo) (int, error)
+	}
+
+	Import interface {
+
+		// CreateImport stores or replaces transcript imported by the user and returns its id.
+		CreateImport(ctx context.Context, uid int, request models.VideoRequest, video *models.YTVideo) (int, error)
+
+		// GetImport returns pgx.ErrNoRows if the user has no such imported transcript.
+		GetImport(ctx context.Context, uid int, request models.VideoRequest) (*models.YTVideo, error)
+	}
+
+	Embedding interface {
+
+		// GetEmbeddings returns embeddings of the embedder with id greater than afterID ordered by id.
+		GetEmbeddings(ctx context.Context, embedder string, afterID int, limit int) ([]models.Embedding, error)
+
+		// PutEmbeddings replaces embeddings of the video, fills their ID fields and marks video as embedded.
+		PutEmbeddings(ctx context.Context, embedder string, videoID int, embeddings []models.Embedding) error
+
+		// GetUnembedded returns oldest videos which weren`t embedded by the embedder.
+		GetUnembedded(ctx context.Context, embedder string, limit int) ([]models.VideoRequest, error)
+	}
 )
 
-func NewRepositories(client *pgx.Conn, hasher hash.PasswordHasher) *Repository {
+func NewRepositories(client *pgxpool.Pool, hasher hash.PasswordHasher) *Repository {
 	return &Repository{
-		Video: NewYTVideoRepository(client),
-		User:  NewUserRepository(client, hasher),
+		Video:       NewYTVideoRepository(client),
+		User:        NewUserRepository(client, hasher),
+		Search:      NewSearchRepository(client),
+		Job:         NewJobRepository(client),
+		Webhook:     NewWebhookRepository(client),
+		Summary:     NewSummaryRepository(client),
+		Keyword:     NewKeywordRepository(client),
+		Embedding:   NewEmbeddingRepository(client),
+		Translation: NewTranslationRepository(client),
+		Import:      NewImportRepository(client),
 	}
 }
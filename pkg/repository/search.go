@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"transcribify/internal/models"
+)
+
+// SegmentsPerVideo is the maximum of matching segments returned for each found video
+const SegmentsPerVideo = 5
+
+type SearchRepository struct {
+	client *pgxpool.Pool
+}
+
+func NewSearchRepository(client *pgxpool.Pool) *SearchRepository {
+	return &SearchRepository{client: client}
+}
+
+// Search returns videos ranked by relevance. Each video contains its most relevant segments ordered by start.
+func (s *SearchRepository) Search(ctx context.Context, request models.SearchRequest) ([]models.SearchResult, error) {
+	var (
+		rawQuery = `SELECT id, video_id, language, title, rank, segment_index, start, dur, snippet
+					FROM search_videos($1, $2, $3, $4, $5)`
+		query   = formatQuery(rawQuery)
+		results = make([]models.SearchResult, 0)
+		ids     = make(map[int]int)
+	)
+
+	rows, err := s.client.Query(ctx, query,
+		request.Query, request.Language, request.Limit, request.Offset, SegmentsPerVideo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id      int
+			result  models.SearchResult
+			segment models.SearchSegment
+		)
+
+		err = rows.Scan(&id, &result.VideoID, &result.Language, &result.Title, &result.Rank,
+			&segment.Index, &segment.Start, &segment.Dur, &segment.Snippet)
+		if err != nil {
+			return nil, err
+		}
+
+		i, ok := ids[id]
+		if !ok {
+			i = len(results)
+			ids[id] = i
+			results = append(results, result)
+		}
+
+		results[i].Segments = append(results[i].Segments, segment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"transcribify/internal/models"
+	"transcribify/pkg/dbclient"
+)
+
+func TestSearchRepository_Search_EscapesSnippet(t *testing.T) {
+	ctx := context.Background()
+	client, err := dbclient.NewClient(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer client.Close()
+
+	var (
+		videos = NewYTVideoRepository(client)
+		repo   = NewSearchRepository(client)
+		video  = &models.YTVideo{
+			Title: "Markup",
+			Transcription: []models.Transcription{
+				{Subtitle: `<b>escapedword</b> & <img src=x onerror="alert(1)">`, Start: 0, Dur: 1},
+			},
+		}
+	)
+
+	_, err = videos.CreateVideo(ctx, models.VideoRequest{VideoID: "searchmark1", Language: "en"}, video)
+	assert.NoError(t, err)
+
+	results, err := repo.Search(ctx, models.SearchRequest{Query: "escapedword", Language: "en", Limit: 10})
+	assert.NoError(t, err)
+
+	if assert.Len(t, results, 1) && assert.Len(t, results[0].Segments, 1) {
+		snippet := results[0].Segments[0].Snippet
+
+		assert.Contains(t, snippet, "&lt;b&gt;")
+		assert.Contains(t, snippet, "&amp;")
+		assert.Contains(t, snippet, "<mark>escapedword</mark>")
+		assert.NotContains(t, snippet, "<b>")
+		assert.NotContains(t, snippet, "<img")
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"transcribify/internal/models"
+)
+
+type SummaryRepository struct {
+	client *pgxpool.Pool
+}
+
+func NewSummaryRepository(client *pgxpool.Pool) *SummaryRepository {
+	return &SummaryRepository{client: client}
+}
+
+func (s *SummaryRepository) GetSummary(ctx context.Context, request models.VideoRequest, style, model string) (*models.Summary, error) {
+	var (
+		rawQuery = `SELECT video_id, language, style, model, summary, created_at
+					FROM summaries
+					WHERE video_id = $1 and language = $2 and style = $3 and model = $4`
+		query   = formatQuery(rawQuery)
+		summary models.Summary
+	)
+
+	err := s.client.QueryRow(ctx, query, request.VideoID, request.Language, style, model).
+		Scan(&summary.VideoID, &summary.Language, &summary.Style, &summary.Model, &summary.Summary, &summary.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+func (s *SummaryRepository) PutSummary(ctx context.Context, summary *models.Summary) error {
+	var (
+		rawQuery = `INSERT INTO summaries (video_id, language, style, model, summary)
+					VALUES ($1, $2, $3, $4, $5)
+					ON CONFLICT ON CONSTRAINT summaries_key DO UPDATE SET summary = excluded.summary, created_at = now()
+					RETURNING created_at`
+		query = formatQuery(rawQuery)
+	)
+
+	return s.client.QueryRow(ctx, query,
+		summary.VideoID, summary.Language, summary.Style, summary.Model, summary.Summary,
+	).Scan(&summary.CreatedAt)
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"transcribify/internal/models"
+)
+
+// TranslationRepository stores machine translated videos in video table apart from original captions
+type TranslationRepository struct {
+	client *pgxpool.Pool
+}
+
+func NewTranslationRepository(client *pgxpool.Pool) *TranslationRepository {
+	return &TranslationRepository{client: client}
+}
+
+func (t *TranslationRepository) GetTranslation(ctx context.Context, request models.VideoRequest, from, translator string) (*models.YTVideo, error) {
+	var (
+		rawQuery = `SELECT id, title, description, available_langs, length_in_seconds, thumbnails, transcription, coalesce(provider, '')
+					FROM video
+					WHERE video_id = $1 and language = $2 and machine_translated and translated_from = $3 and translator = $4`
+		query    = formatQuery(rawQuery)
+		rawThumb json.RawMessage
+		rawTrans json.RawMessage
+		video    = models.YTVideo{MachineTranslated: true, TranslatedFrom: from}
+	)
+
+	err := t.client.QueryRow(ctx, query, request.VideoID, request.Language, from, translator).
+		Scan(&video.Id, &video.Title, &video.Description, &video.AvailableLangs, &video.LengthInSeconds, &rawThumb, &rawTrans, &video.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(rawThumb, &video.Thumbnails); err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(rawTrans, &video.Transcription); err != nil {
+		return nil, err
+	}
+
+	return &video, nil
+}
+
+func (t *TranslationRepository) PutTranslation(ctx context.Context, request models.VideoRequest, from, translator string, video *models.YTVideo) (int, error) {
+	var (
+		rawQuery = `INSERT INTO video (title, description, available_langs, length_in_seconds, thumbnails, transcription,
+					                   video_id, language, provider, machine_translated, translated_from, translator)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, true, $10, $11)
+					ON CONFLICT (video_id, language, translated_from, translator) WHERE machine_translated
+					DO UPDATE SET title = excluded.title, description = excluded.description, transcription = excluded.transcription
+					RETURNING id`
+		query = formatQuery(rawQuery)
+		id    int
+	)
+
+	rawThumb, err := json.Marshal(video.Thumbnails)
+	if err != nil {
+		return -2, err
+	}
+	rawTransc, err := json.Marshal(video.Transcription)
+	if err != nil {
+		return -2, err
+	}
+
+	err = t.client.QueryRow(ctx, query,
+		video.Title, video.Description, video.AvailableLangs, video.LengthInSeconds, rawThumb, rawTransc,
+		request.VideoID, request.Language, video.Provider, from, translator,
+	).Scan(&id)
+	if err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
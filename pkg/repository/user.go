@@ -2,13 +2,13 @@ package repository
 
 import (
 	"context"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"transcribify/internal/models"
 	"transcribify/pkg/hash"
 )
 
 type UserRepository struct {
-	client *pgx.Conn
+	client *pgxpool.Pool
 	hash   hash.PasswordHasher
 }
 
@@ -19,10 +19,11 @@ func (u *UserRepository) PutUser(ctx context.Context, user *models.User) error {
 
 }
 
-func (u *UserRepository) GetUserVideos(ctx context.Context, uid int, limit int, offset int) (map[int]models.YTVideo, error) {
+func (u *UserRepository) GetUserVideos(ctx context.Context, uid int, limit int, offset int, keyword string) (map[int]models.YTVideo, error) {
 
 	arr := make(map[int]models.YTVideo, 0)
-	rows, err := u.client.Query(ctx, "select uv.id, v.title, v.length_in_seconds from public.video v left join user_videos uv on v.id = uv.video_id and uv.user_id = $1 limit $2 offset $3", uid, limit, offset)
+	rows, err := u.client.Query(ctx, "select uv.id, v.title, v.length_in_seconds from user_videos uv join public.video v on v.id = uv.video_id "+
+		"where uv.user_id = $1 and (v.source <> 'import' or v.owner = $1) and ($4 = '' or exists (select 1 from video_keywords k where k.video_id = v.id and k.keyword = lower($4))) limit $2 offset $3", uid, limit, offset, keyword)
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +60,6 @@ func (u *UserRepository) GetUserByLogin(ctx context.Context, user *models.User)
 		Scan(&user.ID, &user.Password)
 }
 
-func NewUserRepository(client *pgx.Conn, haser hash.PasswordHasher) *UserRepository {
+func NewUserRepository(client *pgxpool.Pool, haser hash.PasswordHasher) *UserRepository {
 	return &UserRepository{client: client, hash: haser}
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 	"testing"
 	"transcribify/internal/models"
 	"transcribify/pkg/dbclient"
+	"transcribify/pkg/hash"
 )
 
 func TestUserRepository_PutUser(t *testing.T) {
@@ -50,8 +52,8 @@ func TestUserRepository_PutUser(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	defer db.Close(ctx)
-	repo := NewUserRepository(db)
+	defer db.Close()
+	repo := NewUserRepository(db, hash.NewBCHasher(bcrypt.MinCost))
 	for _, c := range tc {
 		t.Run(c.Name, func(t *testing.T) {
 
@@ -104,8 +106,8 @@ func TestUserRepository_GetUserByLoginPassword(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	defer db.Close(ctx)
-	repo := NewUserRepository(db)
+	defer db.Close()
+	repo := NewUserRepository(db, hash.NewBCHasher(bcrypt.MinCost))
 
 	// put example to get
 	err = repo.PutUser(ctx, &tc[0].User)
@@ -116,7 +118,7 @@ func TestUserRepository_GetUserByLoginPassword(t *testing.T) {
 		t.Run(c.Name, func(t *testing.T) {
 
 			user := &c.User
-			err = repo.GetUserByLoginPassword(ctx, user)
+			err = repo.GetUserByLogin(ctx, user)
 
 			assert.Equal(t, c.ErrExpected, err)
 			assert.Equal(t, c.ExpectedUser, *user)
@@ -127,7 +129,7 @@ func TestUserRepository_GetUserByLoginPassword(t *testing.T) {
 func TestUserRepository_PutUserVideo(t *testing.T) {
 	type UserVideo struct {
 		UID int
-		VID string
+		VID int
 	}
 	type Case struct {
 		UV          UserVideo
@@ -137,12 +139,12 @@ func TestUserRepository_PutUserVideo(t *testing.T) {
 	tc := []Case{
 		{
 			Name:        "Adding user video",
-			UV:          UserVideo{UID: 1, VID: "00000000000"},
+			UV:          UserVideo{UID: 1, VID: 1},
 			ErrExpected: nil,
 		},
 		{
 			Name:        "Adding user video without UID",
-			UV:          UserVideo{VID: "00000000000"},
+			UV:          UserVideo{VID: 1},
 			ErrExpected: &pgconn.PgError{Severity: "ERROR", Code: "22000", Message: "empty user-id or video-id", Detail: "", Hint: "enter user-id or video-id", Position: 0, InternalPosition: 0, InternalQuery: "", Where: "PL/pgSQL function put_user_video(integer,character) line 5 at RAISE", SchemaName: "", TableName: "", ColumnName: "", DataTypeName: "", ConstraintName: "", File: "pl_exec.c", Line: 3893, Routine: "exec_stmt_raise"},
 		},
 		{
@@ -163,7 +165,7 @@ func TestUserRepository_PutUserVideo(t *testing.T) {
 		t.Error(err)
 		return
 	}
-	repo := NewUserRepository(client)
+	repo := NewUserRepository(client, hash.NewBCHasher(bcrypt.MinCost))
 	//set-up user and video
 	v := NewYTVideoRepository(client)
 	video := new(models.YTVideo)
@@ -187,6 +189,67 @@ func TestUserRepository_PutUserVideo(t *testing.T) {
 	}
 }
 
-//func TestUserRepository_GetUserVideos(t *testing.T) {
-//
-//}
+func TestUserRepository_GetUserVideos(t *testing.T) {
+	ctx := context.Background()
+	client, err := dbclient.NewClient(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer client.Close()
+
+	var (
+		repo     = NewUserRepository(client, hash.NewBCHasher(bcrypt.MinCost))
+		videos   = NewYTVideoRepository(client)
+		keywords = NewKeywordRepository(client)
+		owner    = &models.User{Email: "history-owner@gmail.com", Password: "123456789"}
+		other    = &models.User{Email: "history-other@gmail.com", Password: "123456789"}
+	)
+
+	for _, user := range []*models.User{owner, other} {
+		assert.NoError(t, repo.PutUser(ctx, user))
+		assert.NoError(t, repo.GetUserByLogin(ctx, user))
+	}
+
+	ownVideo, err := videos.CreateVideo(ctx, models.VideoRequest{VideoID: "historyown1", Language: "en"}, &models.YTVideo{Title: "Own"})
+	assert.NoError(t, err)
+	otherVideo, err := videos.CreateVideo(ctx, models.VideoRequest{VideoID: "historyoth1", Language: "en"}, &models.YTVideo{Title: "Other"})
+	assert.NoError(t, err)
+
+	// import of another user, which is private even if it gets into history
+	otherImport, err := NewImportRepository(client).
+		CreateImport(ctx, other.ID, models.VideoRequest{VideoID: "historyimp1", Language: "en"}, &models.YTVideo{Title: "Import"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.PutUserVideo(ctx, owner.ID, ownVideo))
+	assert.NoError(t, repo.PutUserVideo(ctx, owner.ID, otherImport))
+	assert.NoError(t, repo.PutUserVideo(ctx, other.ID, otherVideo))
+	for _, id := range []int{ownVideo, otherVideo} {
+		assert.NoError(t, keywords.PutKeywords(ctx, id, []models.Keyword{{Keyword: "history", Score: 1}}))
+	}
+
+	tests := []struct {
+		name    string
+		keyword string
+	}{
+		{
+			name: "Without keyword",
+		},
+		{
+			name:    "With keyword",
+			keyword: "history",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, err := repo.GetUserVideos(ctx, owner.ID, 10, 0, tt.keyword)
+
+			assert.NoError(t, err)
+			assert.Len(t, history, 1)
+			for _, video := range history {
+				assert.Equal(t, "Own", video.Title)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"strings"
 	"transcribify/internal/models"
 )
@@ -11,11 +12,11 @@ import (
 //go:generate mockgen -destination=mocks/mock_repository.go -package=mocks yt-video-transcriptor/models/repository Repository
 type (
 	YTVideoRepository struct {
-		client *pgx.Conn
+		client *pgxpool.Pool
 	}
 )
 
-func NewYTVideoRepository(client *pgx.Conn) *YTVideoRepository {
+func NewYTVideoRepository(client *pgxpool.Pool) *YTVideoRepository {
 	return &YTVideoRepository{
 		client: client,
 	}
@@ -32,35 +33,41 @@ func (p *YTVideoRepository) CreateVideo(ctx context.Context, request models.Vide
 	if err != nil {
 		return -2, err
 	}
-	rawTransc, err := json.Marshal(video.Thumbnails)
+	rawTransc, err := json.Marshal(video.Transcription)
 	if err != nil {
 		return -2, err
 	}
 
-	_, err = p.client.Exec(ctx, "call put_video($1, $2, $3, $4, $5, $6, $7, $8)",
+	err = p.client.QueryRow(ctx, "select put_video($1, $2, $3, $4, $5, $6, $7, $8, $9)",
 		video.Title, video.Description, video.AvailableLangs, video.LengthInSeconds,
-		rawThumb, rawTransc, request.VideoID, request.Language,
-	)
+		rawThumb, rawTransc, request.VideoID, request.Language, video.Provider,
+	).Scan(&id)
 	if err != nil {
 		return -1, err
 	}
+
 	return id, nil
 }
 
 func (p *YTVideoRepository) GetVideoByIDLang(ctx context.Context, request models.VideoRequest) (*models.YTVideo, error) {
 	var (
-		rawQuery = `SELECT id, title, description ,available_langs ,length_in_seconds , thumbnails ,transcription 
+		rawQuery = `SELECT id, title, description ,available_langs ,length_in_seconds , thumbnails ,transcription, coalesce(provider, ''),
+					       chapters, coalesce(chapters_source, '')
 					FROM video as vd
 					WHERE vd.video_id = $1 and
-					      vd.language = $2`
+					      vd.language = $2 and
+					      vd.source = 'youtube' and
+					      not vd.machine_translated`
 		query    = formatQuery(rawQuery)
 		rawThumb json.RawMessage
 		rawTrans json.RawMessage
+		rawChap  json.RawMessage
 		video    models.YTVideo
 	)
 
 	err := p.client.QueryRow(ctx, query, request.VideoID, request.Language).
-		Scan(&video.Id, &video.Title, &video.Description, &video.AvailableLangs, &video.LengthInSeconds, &rawThumb, &rawTrans)
+		Scan(&video.Id, &video.Title, &video.Description, &video.AvailableLangs, &video.LengthInSeconds, &rawThumb, &rawTrans, &video.Provider,
+			&rawChap, &video.ChaptersSource)
 	if err != nil {
 		return nil, err
 	}
@@ -75,9 +82,27 @@ func (p *YTVideoRepository) GetVideoByIDLang(ctx context.Context, request models
 		return nil, err
 	}
 
+	if rawChap != nil {
+		err = json.Unmarshal(rawChap, &video.Chapters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &video, nil
 }
 
+func (p *YTVideoRepository) SetChapters(ctx context.Context, id int, source string, chapters []models.Chapter) error {
+	rawChap, err := json.Marshal(chapters)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.client.Exec(ctx, "UPDATE video SET chapters = $1, chapters_source = $2 WHERE id = $3", rawChap, source, id)
+
+	return err
+}
+
 func (p *YTVideoRepository) Update(ctx context.Context, req models.VideoRequest, video *models.YTVideo) error {
 	// write a query to update the video
 
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+	"transcribify/internal/models"
+)
+
+const (
+	webhookColumns  = `id, user_id, url, secret, events, created_at`
+	deliveryColumns = `id, webhook_id, event, payload, state, attempts, coalesce(response_status, 0), coalesce(error, ''), created_at, updated_at`
+)
+
+type WebhookRepository struct {
+	client *pgxpool.Pool
+}
+
+func NewWebhookRepository(client *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{client: client}
+}
+
+func (wr *WebhookRepository) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	return wr.client.QueryRow(ctx,
+		"insert into webhooks (user_id, url, secret, events) values ($1, $2, $3, $4) returning id, created_at",
+		webhook.UserID, webhook.URL, webhook.Secret, webhook.Events,
+	).Scan(&webhook.ID, &webhook.CreatedAt)
+}
+
+func (wr *WebhookRepository) GetWebhook(ctx context.Context, id int) (*models.Webhook, error) {
+	return scanWebhook(wr.client.QueryRow(ctx, "select "+webhookColumns+" from webhooks where id = $1", id))
+}
+
+func (wr *WebhookRepository) GetWebhooks(ctx context.Context, uid int) ([]models.Webhook, error) {
+	rows, err := wr.client.Query(ctx, "select "+webhookColumns+" from webhooks where user_id = $1 order by id", uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectWebhooks(rows)
+}
+
+func (wr *WebhookRepository) GetWebhooksByEvent(ctx context.Context, uid int, event string) ([]models.Webhook, error) {
+	rows, err := wr.client.Query(ctx,
+		"select "+webhookColumns+" from webhooks where user_id = $1 and $2 = any(events) order by id", uid, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectWebhooks(rows)
+}
+
+func (wr *WebhookRepository) DeleteWebhook(ctx context.Context, uid int, id int) error {
+	tag, err := wr.client.Exec(ctx, "delete from webhooks where id = $1 and user_id = $2", id, uid)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (wr *WebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return wr.client.QueryRow(ctx,
+		"insert into webhook_deliveries (webhook_id, event, payload) values ($1, $2, $3) returning "+deliveryColumns,
+		delivery.WebhookID, delivery.Event, delivery.Payload,
+	).Scan(deliveryFields(delivery)...)
+}
+
+func (wr *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	var (
+		rawQuery = `update webhook_deliveries
+					set state = $2, attempts = $3, response_status = nullif($4, 0), error = nullif($5, ''), updated_at = now()
+					where id = $1
+					returning updated_at`
+		query = formatQuery(rawQuery)
+	)
+
+	return wr.client.QueryRow(ctx, query,
+		delivery.ID, delivery.State, delivery.Attempts, delivery.ResponseStatus, delivery.Error,
+	).Scan(&delivery.UpdatedAt)
+}
+
+func (wr *WebhookRepository) GetDelivery(ctx context.Context, id int) (*models.WebhookDelivery, error) {
+	delivery := new(models.WebhookDelivery)
+
+	err := wr.client.QueryRow(ctx, "select "+deliveryColumns+" from webhook_deliveries where id = $1", id).
+		Scan(deliveryFields(delivery)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (wr *WebhookRepository) GetDeliveries(ctx context.Context, webhookID int, limit int, offset int) ([]models.WebhookDelivery, error) {
+	rows, err := wr.client.Query(ctx,
+		"select "+deliveryColumns+" from webhook_deliveries where webhook_id = $1 order by id desc limit $2 offset $3",
+		webhookID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectDeliveries(rows)
+}
+
+func (wr *WebhookRepository) ClaimStaleDeliveries(ctx context.Context, staleAfter time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var (
+		rawQuery = `update webhook_deliveries
+					set updated_at = now()
+					where id in (select id from webhook_deliveries
+					             where state = 'pending' and updated_at < now() - make_interval(secs => $1)
+					             order by id
+					             limit $2
+					             for update skip locked)
+					returning ` + deliveryColumns
+		query = formatQuery(rawQuery)
+	)
+
+	rows, err := wr.client.Query(ctx, query, staleAfter.Seconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectDeliveries(rows)
+}
+
+func collectDeliveries(rows pgx.Rows) ([]models.WebhookDelivery, error) {
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := rows.Scan(deliveryFields(&delivery)...); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func deliveryFields(d *models.WebhookDelivery) []any {
+	return []any{&d.ID, &d.WebhookID, &d.Event, &d.Payload, &d.State, &d.Attempts,
+		&d.ResponseStatus, &d.Error, &d.CreatedAt, &d.UpdatedAt}
+}
+
+func scanWebhook(row pgx.Row) (*models.Webhook, error) {
+	webhook := new(models.Webhook)
+
+	err := row.Scan(&webhook.ID, &webhook.UserID, &webhook.URL, &webhook.Secret, &webhook.Events, &webhook.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func collectWebhooks(rows pgx.Rows) ([]models.Webhook, error) {
+	defer rows.Close()
+
+	webhooks := make([]models.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	return webhooks, rows.Err()
+}
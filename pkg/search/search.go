@@ -0,0 +1,170 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"transcribify/internal/models"
+	"unicode"
+)
+
+// Mode represents the way query is matched against transcription segments.
+type Mode string
+
+const (
+	Phrase Mode = "phrase"
+	Prefix Mode = "prefix"
+	Regex  Mode = "regex"
+)
+
+var (
+	ErrEmptyQuery  = errors.New("empty search query")
+	ErrUnknownMode = errors.New("unknown search mode")
+)
+
+// Matcher returns byte offsets of every match start in text.
+type Matcher interface {
+	Match(text string) []int
+}
+
+type MatcherFunc func(text string) []int
+
+func (f MatcherFunc) Match(text string) []int {
+	return f(text)
+}
+
+// NewMatcher returns case-insensitive matcher for query. Phrase is the default mode.
+func NewMatcher(mode Mode, query string) (Matcher, error) {
+	query = strings.Join(strings.Fields(query), " ")
+	if query == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	switch mode {
+	case Phrase, "":
+		return phrase(strings.ToLower(query)), nil
+	case Prefix:
+		return prefix(strings.ToLower(query)), nil
+	case Regex:
+		re, err := regexp.Compile("(?i)" + query)
+		if err != nil {
+			return nil, err
+		}
+
+		return MatcherFunc(func(text string) []int {
+			var res []int
+			for _, loc := range re.FindAllStringIndex(text, -1) {
+				res = append(res, loc[0])
+			}
+
+			return res
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMode, mode)
+	}
+}
+
+func phrase(query string) MatcherFunc {
+	return func(text string) []int {
+		var (
+			res    []int
+			lower  = strings.ToLower(text)
+			offset = 0
+		)
+
+		for {
+			i := strings.Index(lower[offset:], query)
+			if i == -1 {
+				return res
+			}
+			res = append(res, offset+i)
+			offset += i + len(query)
+		}
+	}
+}
+
+// prefix matches query which starts at word boundary, so `trans` matches `transcription`
+func prefix(query string) MatcherFunc {
+	match := phrase(query)
+
+	return func(text string) []int {
+		var (
+			res   []int
+			lower = strings.ToLower(text)
+		)
+		for _, i := range match(lower) {
+			if i == 0 || !isWordRune(lastRune(lower[:i])) {
+				res = append(res, i)
+			}
+		}
+
+		return res
+	}
+}
+
+func lastRune(s string) rune {
+	r := []rune(s)
+	return r[len(r)-1]
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// Hit is a matched segment with its surrounding segments.
+type Hit struct {
+	Index   int
+	Segment models.Transcription
+	Before  []models.Transcription
+	After   []models.Transcription
+}
+
+// Find searches every segment of transcription. Matches which start in the segment and
+// continue in the next one (phrase split across segments) are counted for the segment
+// where they start. context is the count of segments returned before and after the hit.
+func Find(transcription []models.Transcription, matcher Matcher, context int) []Hit {
+	hits := make([]Hit, 0)
+
+	for i, segment := range transcription {
+		text := normalize(segment.Subtitle)
+		window := text
+		if i+1 < len(transcription) {
+			window += " " + normalize(transcription[i+1].Subtitle)
+		}
+
+		matched := false
+		for _, offset := range matcher.Match(window) {
+			if offset < len(text) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		before := i - context
+		if before < 0 {
+			before = 0
+		}
+		after := i + 1 + context
+		if after > len(transcription) {
+			after = len(transcription)
+		}
+
+		hits = append(hits, Hit{
+			Index:   i,
+			Segment: segment,
+			Before:  transcription[before:i],
+			After:   transcription[i+1 : after],
+		})
+	}
+
+	return hits
+}
+
+// normalize collapses whitespaces and lowers the text, so offsets returned by Matcher are stable
+func normalize(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
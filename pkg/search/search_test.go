@@ -0,0 +1,72 @@
+package search
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"transcribify/internal/models"
+)
+
+func TestFind(t *testing.T) {
+	transcription := []models.Transcription{
+		{Subtitle: "Welcome to the talk", Start: 0, Dur: 2},
+		{Subtitle: "about Go", Start: 2, Dur: 2},
+		{Subtitle: "concurrency patterns.", Start: 4, Dur: 2},
+		{Subtitle: "Transcription is", Start: 6, Dur: 2},
+		{Subtitle: "not a prescription", Start: 8, Dur: 2},
+	}
+
+	tests := []struct {
+		name     string
+		mode     Mode
+		query    string
+		context  int
+		expected []int
+	}{
+		{name: "Phrase case-insensitive", mode: Phrase, query: "GO", expected: []int{1}},
+		{name: "Phrase across segments", mode: Phrase, query: "go concurrency", expected: []int{1}},
+		{name: "Prefix at word boundary", mode: Prefix, query: "script", expected: []int{}},
+		{name: "Prefix", mode: Prefix, query: "trans", expected: []int{3}},
+		{name: "Regex", mode: Regex, query: `\w+scription`, expected: []int{3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := NewMatcher(tt.mode, tt.query)
+			assert.NoError(t, err)
+
+			indexes := make([]int, 0)
+			for _, hit := range Find(transcription, matcher, tt.context) {
+				indexes = append(indexes, hit.Index)
+			}
+
+			assert.Equal(t, tt.expected, indexes)
+		})
+	}
+}
+
+func TestFindContext(t *testing.T) {
+	transcription := []models.Transcription{
+		{Subtitle: "one"}, {Subtitle: "two"}, {Subtitle: "three"},
+	}
+	matcher, err := NewMatcher(Phrase, "one")
+	assert.NoError(t, err)
+
+	hits := Find(transcription, matcher, 1)
+
+	assert.Equal(t, []Hit{{
+		Index:   0,
+		Segment: transcription[0],
+		Before:  transcription[:0],
+		After:   transcription[1:2],
+	}}, hits)
+}
+
+func TestNewMatcher(t *testing.T) {
+	_, err := NewMatcher(Phrase, "  ")
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+
+	_, err = NewMatcher("fuzzy", "go")
+	assert.ErrorIs(t, err, ErrUnknownMode)
+
+	_, err = NewMatcher(Regex, "(")
+	assert.Error(t, err)
+}
@@ -0,0 +1,316 @@
+package semantic
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+	"transcribify/pkg/embedding"
+	"transcribify/pkg/qa"
+	"transcribify/pkg/repository"
+)
+
+const (
+	// WindowSize is the number of transcript segments in the embedded window
+	WindowSize = qa.WindowSize
+	// EmbedBatch is the number of windows embedded in a single call
+	EmbedBatch = 64
+	// LoadBatch is the number of embeddings loaded from repository in a single query
+	LoadBatch = 1000
+	// PassagesPerVideo in search results
+	PassagesPerVideo = 3
+	// MaxLimit of videos in results
+	MaxLimit = 50
+)
+
+var ErrEmptyQuery = errors.New("empty query")
+
+// Index keeps embeddings of transcript windows in repository and in-memory HNSW graph.
+// Graph is loaded on Start, stored videos without embeddings are embedded in the background.
+type Index struct {
+	embedder embedding.Embedder
+	repo     repository.Embedding
+	videos   repository.Video
+	logger   *zap.Logger
+	interval time.Duration
+	batch    int
+
+	graph *embedding.HNSW
+
+	mu sync.RWMutex
+	// entries of graph nodes without vectors
+	entries []models.Embedding
+	// nodes of the video by its database id
+	byVideo map[int][]int
+	stale   map[int]bool
+}
+
+func NewIndex(
+	embedder embedding.Embedder,
+	repo repository.Embedding,
+	videos repository.Video,
+	logger *zap.Logger,
+	conf config.EmbeddingConfiguration,
+) *Index {
+	return &Index{
+		embedder: embedder,
+		repo:     repo,
+		videos:   videos,
+		logger:   logger,
+		interval: conf.Interval,
+		batch:    conf.Batch,
+		graph:    embedding.NewHNSW(embedding.DefaultM, embedding.DefaultEfConstruction, embedding.DefaultEfSearch),
+		byVideo:  make(map[int][]int),
+		stale:    make(map[int]bool),
+	}
+}
+
+// Start loads stored embeddings and embeds stored videos in the background until ctx is done
+func (i *Index) Start(ctx context.Context) {
+	go func() {
+		if err := i.load(ctx); err != nil {
+			i.logger.Info("Failed to load embeddings", zap.Error(err))
+		}
+
+		ticker := time.NewTicker(i.interval)
+		defer ticker.Stop()
+
+		for {
+			i.backfill(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Indexed reports whether video with database id is in the graph
+func (i *Index) Indexed(videoID int) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	_, ok := i.byVideo[videoID]
+
+	return ok
+}
+
+// IndexVideo embeds transcript windows of the video, stores them and replaces video nodes in the graph
+func (i *Index) IndexVideo(ctx context.Context, request models.VideoRequest, video *models.YTVideo) error {
+	windows := qa.Windows(video.Transcription, WindowSize, WindowSize)
+
+	embeddings := make([]models.Embedding, 0, len(windows))
+	for start := 0; start < len(windows); start += EmbedBatch {
+		end := start + EmbedBatch
+		if end > len(windows) {
+			end = len(windows)
+		}
+
+		texts := make([]string, 0, end-start)
+		for _, w := range windows[start:end] {
+			texts = append(texts, w.Text)
+		}
+
+		vectors, err := i.embedder.Embed(ctx, texts)
+		if err != nil {
+			return err
+		}
+
+		for j, w := range windows[start:end] {
+			embeddings = append(embeddings, models.Embedding{
+				VideoID:      video.Id,
+				YouTubeID:    request.VideoID,
+				Language:     request.Language,
+				Title:        video.Title,
+				Window:       start + j,
+				Start:        w.Start,
+				FirstSegment: w.FirstSegment,
+				Text:         w.Text,
+				Vector:       vectors[j],
+			})
+		}
+	}
+
+	if err := i.repo.PutEmbeddings(ctx, i.embedder.Name(), video.Id, embeddings); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, node := range i.byVideo[video.Id] {
+		i.stale[node] = true
+	}
+	// empty slice marks video without windows as indexed
+	i.byVideo[video.Id] = []int{}
+
+	for _, em := range embeddings {
+		i.add(em)
+	}
+
+	return nil
+}
+
+// Search returns videos with transcript windows similar to the query.
+// Language filters videos if not empty.
+func (i *Index) Search(ctx context.Context, query, language string, limit int) ([]models.SemanticResult, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	vectors, err := i.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, err
+	}
+
+	return i.aggregate(vectors[0], "", language, limit, PassagesPerVideo), nil
+}
+
+// Similar returns videos similar to the video. Video is embedded if it isn`t in the graph yet.
+func (i *Index) Similar(ctx context.Context, request models.VideoRequest, video *models.YTVideo, limit int) ([]models.SemanticResult, error) {
+	if !i.Indexed(video.Id) {
+		if err := i.IndexVideo(ctx, request, video); err != nil {
+			return nil, err
+		}
+	}
+
+	i.mu.RLock()
+	vectors := make([][]float32, 0, len(i.byVideo[video.Id]))
+	for _, node := range i.byVideo[video.Id] {
+		vectors = append(vectors, i.graph.Vector(node))
+	}
+	i.mu.RUnlock()
+
+	if len(vectors) == 0 {
+		return []models.SemanticResult{}, nil
+	}
+
+	return i.aggregate(embedding.Mean(vectors), request.VideoID, "", limit, 0), nil
+}
+
+// aggregate searches windows and groups them by video. Video score is the score of its best window.
+// Every language of exclude YouTube video is skipped.
+func (i *Index) aggregate(query []float32, exclude string, language string, limit int, passages int) []models.SemanticResult {
+	if limit < 1 || limit > MaxLimit {
+		limit = 10
+	}
+
+	k := limit * 10
+	if k < 50 {
+		k = 50
+	}
+
+	neighbors := i.graph.Search(query, k)
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var (
+		results = make([]models.SemanticResult, 0, limit)
+		byVideo = make(map[int]int)
+	)
+
+	for _, n := range neighbors {
+		if i.stale[n.ID] || n.Score <= 0 {
+			continue
+		}
+
+		em := i.entries[n.ID]
+		if (exclude != "" && em.YouTubeID == exclude) || (language != "" && !strings.EqualFold(em.Language, language)) {
+			continue
+		}
+
+		idx, ok := byVideo[em.VideoID]
+		if !ok {
+			idx = len(results)
+			byVideo[em.VideoID] = idx
+			results = append(results, models.SemanticResult{
+				VideoID:  em.YouTubeID,
+				Language: em.Language,
+				Title:    em.Title,
+				Score:    n.Score,
+			})
+		}
+
+		if len(results[idx].Passages) < passages {
+			results[idx].Passages = append(results[idx].Passages, models.SemanticPassage{
+				Start:        em.Start,
+				FirstSegment: em.FirstSegment,
+				Text:         em.Text,
+				Score:        n.Score,
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		return results[a].Score > results[b].Score
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+// add inserts embedding into graph, caller holds the lock
+func (i *Index) add(em models.Embedding) {
+	node := i.graph.Add(em.Vector)
+	em.Vector = nil
+
+	i.entries = append(i.entries, em)
+	i.byVideo[em.VideoID] = append(i.byVideo[em.VideoID], node)
+}
+
+func (i *Index) load(ctx context.Context) error {
+	for after := 0; ; {
+		embeddings, err := i.repo.GetEmbeddings(ctx, i.embedder.Name(), after, LoadBatch)
+		if err != nil {
+			return err
+		}
+
+		i.mu.Lock()
+		for _, em := range embeddings {
+			if len(em.Vector) == i.embedder.Dimensions() {
+				i.add(em)
+			}
+			after = em.ID
+		}
+		i.mu.Unlock()
+
+		if len(embeddings) < LoadBatch {
+			i.logger.Info("Embeddings loaded", zap.Int("windows", i.graph.Len()), zap.String("embedder", i.embedder.Name()))
+			return nil
+		}
+	}
+}
+
+// backfill embeds stored videos without embeddings
+func (i *Index) backfill(ctx context.Context) {
+	requests, err := i.repo.GetUnembedded(ctx, i.embedder.Name(), i.batch)
+	if err != nil {
+		i.logger.Info("Failed to get videos without embeddings", zap.Error(err))
+		return
+	}
+
+	for _, request := range requests {
+		if ctx.Err() != nil {
+			return
+		}
+
+		video, err := i.videos.GetVideoByIDLang(ctx, request)
+		if err == nil {
+			err = i.IndexVideo(ctx, request, video)
+		}
+		if err != nil {
+			i.logger.Info("Failed to embed video", zap.Any("video request", request), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package semantic
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+	"transcribify/pkg/embedding"
+	"transcribify/pkg/repository"
+)
+
+type memoryEmbeddings struct {
+	repository.Embedding
+
+	stored map[int][]models.Embedding
+}
+
+func (m *memoryEmbeddings) PutEmbeddings(_ context.Context, _ string, videoID int, embeddings []models.Embedding) error {
+	m.stored[videoID] = embeddings
+
+	return nil
+}
+
+func video(id int, subtitles ...string) *models.YTVideo {
+	v := &models.YTVideo{Id: id, Title: subtitles[0]}
+	for i, s := range subtitles {
+		v.Transcription = append(v.Transcription, models.Transcription{Subtitle: s, Start: float64(i * 60)})
+	}
+
+	return v
+}
+
+func TestIndex(t *testing.T) {
+	var (
+		repo  = &memoryEmbeddings{stored: map[int][]models.Embedding{}}
+		conf  = config.EmbeddingConfiguration{Interval: time.Minute, Batch: 1}
+		index = NewIndex(embedding.NewHashing(256, nil), repo, nil, zap.NewNop(), conf)
+		ctx   = context.Background()
+
+		docker  = models.VideoRequest{VideoID: "00000000001", Language: "en"}
+		compose = models.VideoRequest{VideoID: "00000000002", Language: "en"}
+		cooking = models.VideoRequest{VideoID: "00000000003", Language: "de"}
+	)
+
+	assert.NoError(t, index.IndexVideo(ctx, docker, video(1, "docker containers and images", "build docker image")))
+	assert.NoError(t, index.IndexVideo(ctx, compose, video(2, "docker compose runs containers")))
+	assert.NoError(t, index.IndexVideo(ctx, cooking, video(3, "kochen mit tomaten und pasta")))
+	assert.Len(t, repo.stored[1], 1)
+	assert.True(t, index.Indexed(1))
+
+	results, err := index.Search(ctx, "docker container", "", 10)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "00000000001", results[0].VideoID)
+	assert.Equal(t, "docker containers and images build docker image", results[0].Passages[0].Text)
+
+	results, err = index.Search(ctx, "docker container", "de", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	_, err = index.Search(ctx, " ", "", 10)
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+
+	// reindexed video replaces its windows
+	assert.NoError(t, index.IndexVideo(ctx, compose, video(2, "tomaten pasta")))
+
+	similar, err := index.Similar(ctx, cooking, video(3, "kochen mit tomaten und pasta"), 10)
+	assert.NoError(t, err)
+	assert.Len(t, similar, 1)
+	assert.Equal(t, "00000000002", similar[0].VideoID)
+	assert.Empty(t, similar[0].Passages)
+
+	// other language of the same video isn`t similar to it
+	assert.NoError(t, index.IndexVideo(ctx, models.VideoRequest{VideoID: "00000000003", Language: "en"},
+		video(4, "kochen mit tomaten und pasta")))
+
+	similar, err = index.Similar(ctx, cooking, video(3, "kochen mit tomaten und pasta"), 10)
+	assert.NoError(t, err)
+	assert.Len(t, similar, 1)
+	assert.Equal(t, "00000000002", similar[0].VideoID)
+}
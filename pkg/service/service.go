@@ -6,7 +6,15 @@ import (
 	"transcribify/pkg/auth"
 	"transcribify/pkg/finders"
 	"transcribify/pkg/hash"
+	"transcribify/pkg/jobs"
+	"transcribify/pkg/keywords"
+	"transcribify/pkg/playlist"
+	"transcribify/pkg/qa"
 	"transcribify/pkg/repository"
+	"transcribify/pkg/semantic"
+	"transcribify/pkg/summarizer"
+	"transcribify/pkg/translate"
+	"transcribify/pkg/webhook"
 )
 
 type (
@@ -14,6 +22,15 @@ type (
 		Manager       auth.TokenManager
 		Authorization auth.Authorization
 		Finder        finders.Finder
+		Negotiator    *finders.Negotiator
+		Jobs          *jobs.Pool
+		Playlists     playlist.Resolver
+		Webhooks      *webhook.Dispatcher
+		Summarizer    summarizer.Summarizer
+		Answerer      *qa.Answerer
+		Keywords      *keywords.Tagger
+		Semantic      *semantic.Index
+		Translator    *translate.Service
 	}
 )
 
@@ -27,5 +44,6 @@ func New(repository repository.Repository, finder finders.Finder, hasher hash.Pa
 		Manager:       manager,
 		Authorization: auth.NewAuthorizationManager(repository.User, manager, hasher),
 		Finder:        finder,
+		Negotiator:    finders.NewNegotiator(finder, nil),
 	}
 }
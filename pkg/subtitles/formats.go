@@ -0,0 +1,114 @@
+package subtitles
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"transcribify/internal/models"
+)
+
+var (
+	// vttTags are voice, class, language and karaoke timestamp tags
+	vttTags   = regexp.MustCompile(`</?(?:[cvibu]|lang|ruby|rt)(?:[.\s][^>]*)?>|<\d[\d:.]*>`)
+	sbvTiming = regexp.MustCompile(`^\d+:\d{2}:\d{2}\.\d{3},\d+:\d{2}:\d{2}\.\d{3}`)
+)
+
+// parseSRT parses SubRip cues: optional index, `start --> end` and text lines
+func parseSRT(text string) ([]models.Transcription, error) {
+	var transcription []models.Transcription
+
+	for i, block := range blocks(text) {
+		timing := 0
+		if !strings.Contains(block[0], "-->") {
+			timing = 1
+		}
+		if timing >= len(block) {
+			return nil, fmt.Errorf("%w: block %d has no timing", ErrTiming, i+1)
+		}
+
+		start, end, ok := strings.Cut(block[timing], "-->")
+		if !ok {
+			return nil, fmt.Errorf("%w: block %d has no timing", ErrTiming, i+1)
+		}
+
+		segment, err := cue(start, firstField(end), block[timing+1:])
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i+1, err)
+		}
+		transcription = append(transcription, segment)
+	}
+
+	return transcription, nil
+}
+
+// parseVTT parses WebVTT cues skipping header, NOTE, STYLE and REGION blocks.
+// Cue settings and inline tags are dropped.
+func parseVTT(text string) ([]models.Transcription, error) {
+	var transcription []models.Transcription
+
+	all := blocks(text)
+	if len(all) == 0 || !strings.HasPrefix(all[0][0], "WEBVTT") {
+		return nil, fmt.Errorf("%w: missing WEBVTT header", ErrUnknownFormat)
+	}
+
+	for i, block := range all[1:] {
+		switch first := strings.Fields(block[0]); {
+		case len(first) > 0 && (first[0] == "NOTE" || first[0] == "STYLE" || first[0] == "REGION"):
+			continue
+		}
+
+		timing := 0
+		if !strings.Contains(block[0], "-->") {
+			timing = 1
+		}
+		if timing >= len(block) || !strings.Contains(block[timing], "-->") {
+			return nil, fmt.Errorf("%w: block %d has no timing", ErrTiming, i+2)
+		}
+
+		start, end, _ := strings.Cut(block[timing], "-->")
+
+		lines := make([]string, 0, len(block)-timing-1)
+		for _, line := range block[timing+1:] {
+			if line = strings.TrimSpace(html.UnescapeString(vttTags.ReplaceAllString(line, ""))); line != "" {
+				lines = append(lines, line)
+			}
+		}
+
+		segment, err := cue(start, firstField(end), lines)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i+2, err)
+		}
+		transcription = append(transcription, segment)
+	}
+
+	return transcription, nil
+}
+
+// parseSBV parses YouTube SubViewer cues: `start,end` and text lines
+func parseSBV(text string) ([]models.Transcription, error) {
+	var transcription []models.Transcription
+
+	for i, block := range blocks(text) {
+		start, end, ok := strings.Cut(strings.TrimSpace(block[0]), ",")
+		if !ok {
+			return nil, fmt.Errorf("%w: block %d has no timing", ErrTiming, i+1)
+		}
+
+		segment, err := cue(start, end, block[1:])
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i+1, err)
+		}
+		transcription = append(transcription, segment)
+	}
+
+	return transcription, nil
+}
+
+func firstField(s string) string {
+	if fields := strings.Fields(s); len(fields) > 0 {
+		return fields[0]
+	}
+
+	return ""
+}
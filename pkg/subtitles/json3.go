@@ -0,0 +1,50 @@
+package subtitles
+
+import (
+	"encoding/json"
+	"html"
+	"strings"
+	"transcribify/internal/models"
+)
+
+type json3 struct {
+	Events []struct {
+		StartMs    float64 `json:"tStartMs"`    //nolint:tagliatelle
+		DurationMs float64 `json:"dDurationMs"` //nolint:tagliatelle
+		Segs       []struct {
+			UTF8 string `json:"utf8"`
+		} `json:"segs"`
+	} `json:"events"`
+}
+
+// ParseJSON3 parses YouTube timedtext `fmt=json3` events. Events without text are skipped.
+func ParseJSON3(data []byte) ([]models.Transcription, error) {
+	var (
+		document      json3
+		transcription []models.Transcription
+	)
+
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	for _, event := range document.Events {
+		var text strings.Builder
+		for _, seg := range event.Segs {
+			text.WriteString(seg.UTF8)
+		}
+
+		subtitle := strings.TrimSpace(html.UnescapeString(text.String()))
+		if subtitle == "" {
+			continue
+		}
+
+		transcription = append(transcription, models.Transcription{
+			Subtitle: subtitle,
+			Start:    event.StartMs / 1000,
+			Dur:      event.DurationMs / 1000,
+		})
+	}
+
+	return transcription, nil
+}
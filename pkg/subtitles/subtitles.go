@@ -0,0 +1,213 @@
+package subtitles
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"transcribify/internal/models"
+)
+
+// Format of subtitle file
+type Format string
+
+const (
+	SRT       Format = "srt"
+	VTT       Format = "vtt"
+	SBV       Format = "sbv"
+	TimedText Format = "xml"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown subtitle format")
+	ErrNoCues        = errors.New("subtitle file has no cues")
+	ErrTimestamp     = errors.New("invalid timestamp")
+	ErrTiming        = errors.New("invalid timing")
+)
+
+// ParseFormat accepts format names and file extensions, `webvtt` and `timedtext` aliases
+func ParseFormat(format string) (Format, error) {
+	switch f := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(format)), "."); f {
+	case "srt":
+		return SRT, nil
+	case "vtt", "webvtt":
+		return VTT, nil
+	case "sbv":
+		return SBV, nil
+	case "xml", "timedtext", "srv1", "srv3":
+		return TimedText, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// Detect guesses format by file extension, then by content
+func Detect(name string, data []byte) (Format, error) {
+	if format, err := ParseFormat(filepath.Ext(name)); err == nil {
+		return format, nil
+	}
+
+	head := bytes.TrimSpace(bytes.TrimPrefix(data, bom))
+	if len(head) > 512 {
+		head = head[:512]
+	}
+
+	switch {
+	case bytes.HasPrefix(head, []byte("WEBVTT")):
+		return VTT, nil
+	case bytes.HasPrefix(head, []byte("<")):
+		return TimedText, nil
+	case bytes.Contains(head, []byte("-->")):
+		return SRT, nil
+	case sbvTiming.Match(head):
+		return SBV, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, name)
+	}
+}
+
+// Parse parses subtitle file and validates its timing
+func Parse(format Format, data []byte) ([]models.Transcription, error) {
+	var (
+		transcription []models.Transcription
+		err           error
+	)
+
+	data = bytes.TrimPrefix(data, bom)
+
+	switch format {
+	case SRT:
+		transcription, err = parseSRT(string(data))
+	case VTT:
+		transcription, err = parseVTT(string(data))
+	case SBV:
+		transcription, err = parseSBV(string(data))
+	case TimedText:
+		transcription, err = ParseTimedText(data)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = Validate(transcription); err != nil {
+		return nil, err
+	}
+
+	return transcription, nil
+}
+
+// Validate checks that there are cues, they have non-negative duration and start in order
+func Validate(transcription []models.Transcription) error {
+	if len(transcription) == 0 {
+		return ErrNoCues
+	}
+
+	for i, t := range transcription {
+		if !isFinite(t.Start) || !isFinite(t.Dur) {
+			return fmt.Errorf("%w: cue %d", ErrTimestamp, i+1)
+		}
+		if t.Start < 0 || t.Dur < 0 {
+			return fmt.Errorf("%w: cue %d ends before it starts", ErrTiming, i+1)
+		}
+		if i > 0 && t.Start < transcription[i-1].Start {
+			return fmt.Errorf("%w: cue %d starts before the previous one", ErrTiming, i+1)
+		}
+	}
+
+	return nil
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+var bom = []byte("\uFEFF")
+
+// blocks splits text into blocks separated by blank lines
+func blocks(text string) [][]string {
+	var (
+		result [][]string
+		block  []string
+	)
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimRight(line, "\r ")
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				result = append(result, block)
+				block = nil
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if len(block) > 0 {
+		result = append(result, block)
+	}
+
+	return result
+}
+
+// parseTimestamp parses `[hh:]mm:ss[.,]mmm`
+func parseTimestamp(value string) (float64, error) {
+	value = strings.Replace(strings.TrimSpace(value), ",", ".", 1)
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("%w: %q", ErrTimestamp, value)
+	}
+
+	var seconds float64
+	for i, part := range parts {
+		// only seconds have fraction, strconv.ParseFloat alone accepts NaN, Inf and exponents
+		whole, fraction, ok := strings.Cut(part, ".")
+		if !isDigits(whole) || (ok && (i < len(parts)-1 || !isDigits(fraction))) {
+			return 0, fmt.Errorf("%w: %q", ErrTimestamp, value)
+		}
+
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrTimestamp, value)
+		}
+		seconds = seconds*60 + n
+	}
+
+	return seconds, nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cue builds segment from start and end timestamps
+func cue(start, end string, text []string) (models.Transcription, error) {
+	from, err := parseTimestamp(start)
+	if err != nil {
+		return models.Transcription{}, err
+	}
+	to, err := parseTimestamp(end)
+	if err != nil {
+		return models.Transcription{}, err
+	}
+
+	return models.Transcription{
+		Subtitle: strings.Join(text, "\n"),
+		Start:    from,
+		// timestamps have millisecond precision, rounding removes floating point noise
+		Dur: math.Round((to-from)*1000) / 1000,
+	}, nil
+}
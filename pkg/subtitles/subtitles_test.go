@@ -0,0 +1,115 @@
+package subtitles
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"transcribify/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	expected := []models.Transcription{
+		{Subtitle: "Hello & welcome", Start: 1, Dur: 2.5},
+		{Subtitle: "second line\nwraps", Start: 3.5, Dur: 1.25},
+	}
+
+	tests := []struct {
+		name   string
+		format Format
+		data   string
+	}{
+		{
+			name:   "SRT",
+			format: SRT,
+			data: "\uFEFF1\r\n00:00:01,000 --> 00:00:03,500\r\nHello & welcome\r\n\r\n" +
+				"2\r\n00:00:03,500 --> 00:00:04,750\r\nsecond line\r\nwraps\r\n",
+		},
+		{
+			name:   "VTT",
+			format: VTT,
+			data: "WEBVTT - title\n\nNOTE comment\nspans lines\n\nSTYLE\n::cue { color: red }\n\n" +
+				"intro\n00:01.000 --> 00:03.500 align:start position:10%\n<v Speaker>Hello &amp; <c.loud>welcome</c></v>\n\n" +
+				"00:00:03.500 --> 00:00:04.750\nsecond <00:00:04.000>line\nwraps\n",
+		},
+		{
+			name:   "SBV",
+			format: SBV,
+			data:   "0:00:01.000,0:00:03.500\nHello & welcome\n\n0:00:03.500,0:00:04.750\nsecond line\nwraps\n",
+		},
+		{
+			name:   "Timed text",
+			format: TimedText,
+			data: `<?xml version="1.0" encoding="utf-8" ?><transcript>` +
+				`<text start="1" dur="2.5">Hello &amp;amp; welcome</text>` +
+				`<text start="3.5" dur="1.25">second line
+wraps</text></transcript>`,
+		},
+		{
+			name:   "Timed text format 3",
+			format: TimedText,
+			data: `<timedtext format="3"><body>` +
+				`<p t="1000" d="2500">Hello &amp; welcome</p>` +
+				`<p t="2000" d="100"></p>` +
+				`<p t="3500" d="1250"><s>second</s><s t="300"> line
+wraps</s></p></body></timedtext>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcription, err := Parse(tt.format, []byte(tt.data))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, transcription)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		data   string
+		err    error
+	}{
+		{name: "Empty", format: SRT, data: "\n\n", err: ErrNoCues},
+		{name: "Bad timestamp", format: SRT, data: "1\n00:00:xx,000 --> 00:00:02,000\ntext\n", err: ErrTimestamp},
+		{name: "NaN timestamp", format: SRT, data: "1\n00:00:NaN --> 00:00:02,000\ntext\n", err: ErrTimestamp},
+		{name: "Inf timestamp", format: VTT, data: "WEBVTT\n\n00:01.000 --> 00:Inf\ntext\n", err: ErrTimestamp},
+		{name: "Exponent timestamp", format: SBV, data: "0:00:1e9,0:00:02.000\ntext\n", err: ErrTimestamp},
+		{name: "NaN timedtext", format: TimedText, data: `<transcript><text start="NaN" dur="1">text</text></transcript>`, err: ErrTimestamp},
+		{name: "Ends before start", format: SBV, data: "0:00:02.000,0:00:01.000\ntext\n", err: ErrTiming},
+		{
+			name:   "Not monotonic",
+			format: SRT,
+			data:   "1\n00:00:05,000 --> 00:00:06,000\na\n\n2\n00:00:01,000 --> 00:00:02,000\nb\n",
+			err:    ErrTiming,
+		},
+		{name: "No header", format: VTT, data: "00:01.000 --> 00:02.000\ntext\n", err: ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.format, []byte(tt.data))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		data     string
+		expected Format
+	}{
+		{name: "Extension", file: "talk.VTT", data: "1\n00:00:01,000 --> 00:00:02,000\n", expected: VTT},
+		{name: "WebVTT header", file: "talk", data: "WEBVTT\n", expected: VTT},
+		{name: "XML", file: "talk.txt", data: "  <transcript>", expected: TimedText},
+		{name: "SubRip arrow", file: "", data: "1\n00:00:01,000 --> 00:00:02,000\n", expected: SRT},
+		{name: "SBV timing", file: "", data: "0:00:01.000,0:00:02.000\ntext", expected: SBV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := Detect(tt.file, []byte(tt.data))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
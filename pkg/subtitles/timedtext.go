@@ -0,0 +1,109 @@
+package subtitles
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"transcribify/internal/models"
+)
+
+// ParseTimedText parses YouTube timedtext XML: legacy `<transcript><text start="" dur="">`
+// in seconds and format 3 `<timedtext><body><p t="" d="">` in milliseconds.
+// Text is unescaped twice because YouTube escapes entities in XML text.
+func ParseTimedText(data []byte) ([]models.Transcription, error) {
+	var (
+		decoder       = xml.NewDecoder(bytes.NewReader(data))
+		transcription []models.Transcription
+	)
+	decoder.Strict = false
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		element, ok := token.(xml.StartElement)
+		if !ok || (element.Name.Local != "text" && element.Name.Local != "p") {
+			continue
+		}
+
+		var body struct {
+			Inner string `xml:",innerxml"`
+		}
+		if err = decoder.DecodeElement(&body, &element); err != nil {
+			return nil, err
+		}
+
+		segment, err := timedTextSegment(element)
+		if err != nil {
+			return nil, err
+		}
+
+		segment.Subtitle = strings.TrimSpace(html.UnescapeString(html.UnescapeString(stripTags(body.Inner))))
+		if segment.Subtitle == "" {
+			continue
+		}
+		transcription = append(transcription, segment)
+	}
+
+	return transcription, nil
+}
+
+func timedTextSegment(element xml.StartElement) (models.Transcription, error) {
+	var (
+		segment models.Transcription
+		scale   = 1.0
+		start   = "start"
+		dur     = "dur"
+	)
+
+	if element.Name.Local == "p" {
+		scale, start, dur = 1000, "t", "d"
+	}
+
+	for _, attr := range element.Attr {
+		if attr.Name.Local != start && attr.Name.Local != dur {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(attr.Value, 64)
+		if err != nil {
+			return segment, ErrTimestamp
+		}
+
+		if attr.Name.Local == start {
+			segment.Start = value / scale
+		} else {
+			segment.Dur = value / scale
+		}
+	}
+
+	return segment, nil
+}
+
+// stripTags removes `<s>` word tags of format 3 and `<font>` tags
+func stripTags(s string) string {
+	var b strings.Builder
+
+	depth := 0
+	for _, r := range s {
+		switch {
+		case r == '<':
+			depth++
+		case r == '>' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package summarizer
+
+import (
+	"strings"
+	"transcribify/internal/models"
+)
+
+// Chunk splits transcription on segment boundaries into chunks of at most size tokens.
+// Every chunk except the first starts with the trailing segments of the previous chunk
+// that fit into overlap tokens. A segment larger than size becomes a chunk on its own.
+func Chunk(transcription []models.Transcription, estimator TokenEstimator, size, overlap int) [][]models.Transcription {
+	segments := make([]models.Transcription, 0, len(transcription))
+	tokens := make([]int, 0, len(transcription))
+	for _, t := range transcription {
+		s := strings.TrimSpace(t.Subtitle)
+		if s == "" {
+			continue
+		}
+		segments = append(segments, t)
+		// +1 for the separating space
+		tokens = append(tokens, estimator.Estimate(s)+1)
+	}
+
+	if overlap >= size {
+		overlap = size / 2
+	}
+
+	var chunks [][]models.Transcription
+
+	for start := 0; start < len(segments); {
+		end, total := start, 0
+		for end < len(segments) && (end == start || total+tokens[end] <= size) {
+			total += tokens[end]
+			end++
+		}
+
+		chunks = append(chunks, segments[start:end])
+		if end == len(segments) {
+			break
+		}
+
+		// step back while overlapping segments fit, but always move forward
+		next, carried := end, 0
+		for next-1 > start && carried+tokens[next-1] <= overlap {
+			next--
+			carried += tokens[next]
+		}
+		start = next
+	}
+
+	return chunks
+}
+
+// Group splits texts into consecutive groups of at most size tokens.
+// Every group has at least two texts (if there are), so reducing always makes progress.
+func Group(texts []string, estimator TokenEstimator, size int) [][]string {
+	var (
+		groups [][]string
+		group  []string
+		total  int
+	)
+
+	for _, text := range texts {
+		tokens := estimator.Estimate(text)
+		if len(group) > 1 && total+tokens > size {
+			groups = append(groups, group)
+			group, total = nil, 0
+		}
+		group = append(group, text)
+		total += tokens
+	}
+
+	if len(group) == 1 && len(groups) > 0 {
+		groups[len(groups)-1] = append(groups[len(groups)-1], group[0])
+	} else if len(group) > 0 {
+		groups = append(groups, group)
+	}
+
+	return groups
+}
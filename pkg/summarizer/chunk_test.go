@@ -0,0 +1,134 @@
+package summarizer
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"sync"
+	"testing"
+	"transcribify/internal/models"
+	"transcribify/pkg/llm"
+)
+
+// words counts every word as a single token
+var words = TokenEstimatorFunc(func(text string) int {
+	return len(strings.Fields(text))
+})
+
+func segments(subtitles ...string) []models.Transcription {
+	res := make([]models.Transcription, len(subtitles))
+	for i, s := range subtitles {
+		res[i] = models.Transcription{Subtitle: s, Start: float64(i)}
+	}
+
+	return res
+}
+
+func subtitles(chunks [][]models.Transcription) [][]string {
+	res := make([][]string, len(chunks))
+	for i, chunk := range chunks {
+		for _, t := range chunk {
+			res[i] = append(res[i], t.Subtitle)
+		}
+	}
+
+	return res
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []models.Transcription
+		size    int
+		overlap int
+		want    [][]string
+	}{
+		{
+			name:  "fits",
+			input: segments("a", "b"),
+			size:  10,
+			want:  [][]string{{"a", "b"}},
+		},
+		{
+			name:  "no overlap",
+			input: segments("a", "b", "c", "d", "e"),
+			size:  4,
+			want:  [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+		},
+		{
+			name:    "overlap",
+			input:   segments("a", "b", "c", "d", "e"),
+			size:    6,
+			overlap: 2,
+			want:    [][]string{{"a", "b", "c"}, {"c", "d", "e"}},
+		},
+		{
+			name:  "large segment",
+			input: segments("a", "b c d e f", "g"),
+			size:  4,
+			want:  [][]string{{"a"}, {"b c d e f"}, {"g"}},
+		},
+		{
+			name:  "empty segments",
+			input: segments(" ", "a", ""),
+			size:  4,
+			want:  [][]string{{"a"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, subtitles(Chunk(test.input, words, test.size, test.overlap)))
+		})
+	}
+}
+
+func TestGroup(t *testing.T) {
+	assert.Equal(t, [][]string{{"a b", "c d"}, {"e f", "g h", "i j"}},
+		Group([]string{"a b", "c d", "e f", "g h", "i j"}, words, 4))
+	assert.Equal(t, [][]string{{"a b c d e", "f g h i j"}},
+		Group([]string{"a b c d e", "f g h i j"}, words, 4))
+}
+
+// chatFunc records calls and answers with the number of the call
+type chatFunc struct {
+	mu    sync.Mutex
+	calls [][]llm.Message
+}
+
+func (c *chatFunc) Complete(_ context.Context, messages []llm.Message) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, messages)
+
+	return fmt.Sprintf("summary %d", len(c.calls)), nil
+}
+
+func (c *chatFunc) Model() string {
+	return "stub"
+}
+
+func TestLLMSummarizer_MapReduce(t *testing.T) {
+	chat := &chatFunc{}
+	s := NewWithConfig(chat, Config{ChunkTokens: 4, Concurrency: 2, Estimator: words})
+
+	video := &models.YTVideo{Transcription: segments("a", "b", "c", "d", "e", "f", "g", "h", "i", "j")}
+
+	summary, err := s.Summarize(context.Background(), video, TLDR)
+
+	assert.NoError(t, err)
+	// 5 chunks, partials "summary N" are 2 tokens each: 5 partials -> 2 groups -> final
+	assert.Len(t, chat.calls, 5+2+1)
+	assert.Equal(t, "summary 8", summary)
+
+	last := chat.calls[len(chat.calls)-1]
+	assert.Contains(t, last[0].Content, styleInstructions[TLDR])
+}
+
+func TestConfigFor(t *testing.T) {
+	assert.Equal(t, ModelConfigs["gpt-3.5-turbo-16k"], ConfigFor("gpt-3.5-turbo-16k-0613"))
+	assert.Equal(t, ModelConfigs["gpt-4o"], ConfigFor("gpt-4o-mini"))
+	assert.Equal(t, DefaultConfig, ConfigFor("llama"))
+}
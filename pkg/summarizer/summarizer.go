@@ -0,0 +1,292 @@
+package summarizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"transcribify/internal/models"
+	"transcribify/pkg/llm"
+)
+
+// Style of the summary
+type Style string
+
+const (
+	Bullet    Style = "bullet"
+	Paragraph Style = "paragraph"
+	TLDR      Style = "tldr"
+)
+
+var (
+	ErrUnknownStyle    = errors.New("unknown summary style")
+	ErrEmptyTranscript = errors.New("video has no transcription")
+	ErrNotConfigured   = errors.New("summarizer is not configured")
+	styleInstructions  = map[Style]string{
+		Bullet:    "Summarize the transcript as a concise list of bullet points with the key ideas in the order they appear.",
+		Paragraph: "Summarize the transcript in one or two coherent paragraphs.",
+		TLDR:      "Summarize the transcript in a single sentence (TL;DR).",
+	}
+)
+
+// ParseStyle returns Bullet for empty style
+func ParseStyle(style string) (Style, error) {
+	if style == "" {
+		return Bullet, nil
+	}
+
+	if _, ok := styleInstructions[Style(style)]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownStyle, style)
+	}
+
+	return Style(style), nil
+}
+
+// Summarizer represents interface for summarizing YouTube video transcription
+type Summarizer interface {
+	Summarize(ctx context.Context, video *models.YTVideo, style Style) (string, error)
+	// Model uses as a part of cache key
+	Model() string
+}
+
+// Config controls map-reduce summarization of long transcripts
+type Config struct {
+	// ChunkTokens is the largest part of the transcript sent in a single prompt
+	ChunkTokens int
+	// OverlapTokens of the previous chunk repeated at the start of the next one
+	OverlapTokens int
+	// Concurrency of the model requests
+	Concurrency int
+	Estimator   TokenEstimator
+}
+
+var (
+	// DefaultConfig fits models with 2048 tokens context
+	DefaultConfig = Config{ChunkTokens: 1200, OverlapTokens: 60, Concurrency: 4, Estimator: Heuristic{}}
+	// ModelConfigs keeps room for the instructions and the answer inside the model context.
+	// Model name is matched by the longest prefix.
+	ModelConfigs = map[string]Config{
+		"gpt-3.5-turbo":     {ChunkTokens: 2500, OverlapTokens: 100, Concurrency: 4, Estimator: Heuristic{}},
+		"gpt-3.5-turbo-16k": {ChunkTokens: 12000, OverlapTokens: 200, Concurrency: 4, Estimator: Heuristic{}},
+		"gpt-4":             {ChunkTokens: 6000, OverlapTokens: 150, Concurrency: 2, Estimator: Heuristic{}},
+		"gpt-4-turbo":       {ChunkTokens: 60000, OverlapTokens: 300, Concurrency: 2, Estimator: Heuristic{}},
+		"gpt-4o":            {ChunkTokens: 60000, OverlapTokens: 300, Concurrency: 4, Estimator: Heuristic{}},
+	}
+)
+
+// ConfigFor returns config of the model or DefaultConfig for unknown models
+func ConfigFor(model string) Config {
+	conf, prefix := DefaultConfig, ""
+	for name, c := range ModelConfigs {
+		if strings.HasPrefix(model, name) && len(name) > len(prefix) {
+			conf, prefix = c, name
+		}
+	}
+
+	return conf
+}
+
+// LLMSummarizer summarizes transcript chunks concurrently and then reduces
+// partial summaries hierarchically until they fit into a single prompt.
+type LLMSummarizer struct {
+	chat llm.Chat
+	conf Config
+}
+
+// New uses config of the chat model
+func New(chat llm.Chat) *LLMSummarizer {
+	return NewWithConfig(chat, ConfigFor(chat.Model()))
+}
+
+func NewWithConfig(chat llm.Chat, conf Config) *LLMSummarizer {
+	if conf.ChunkTokens < 1 {
+		conf.ChunkTokens = DefaultConfig.ChunkTokens
+	}
+	if conf.OverlapTokens < 0 {
+		conf.OverlapTokens = 0
+	}
+	if conf.Concurrency < 1 {
+		conf.Concurrency = 1
+	}
+	if conf.Estimator == nil {
+		conf.Estimator = Heuristic{}
+	}
+
+	return &LLMSummarizer{chat: chat, conf: conf}
+}
+
+func (s *LLMSummarizer) Model() string {
+	return s.chat.Model()
+}
+
+func (s *LLMSummarizer) Summarize(ctx context.Context, video *models.YTVideo, style Style) (string, error) {
+	text := Transcript(video.Transcription)
+	if text == "" {
+		return "", ErrEmptyTranscript
+	}
+
+	if s.conf.Estimator.Estimate(text) <= s.conf.ChunkTokens {
+		return s.chat.Complete(ctx, Prompt(video.Title, text, style))
+	}
+
+	chunks := Chunk(video.Transcription, s.conf.Estimator, s.conf.ChunkTokens, s.conf.OverlapTokens)
+
+	// map
+	partials, err := s.parallel(ctx, len(chunks), func(ctx context.Context, i int) (string, error) {
+		return s.chat.Complete(ctx, ChunkPrompt(video.Title, Transcript(chunks[i]), i+1, len(chunks)))
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// reduce
+	for len(partials) > 1 && s.conf.Estimator.Estimate(strings.Join(partials, "\n\n")) > s.conf.ChunkTokens {
+		groups := Group(partials, s.conf.Estimator, s.conf.ChunkTokens)
+
+		partials, err = s.parallel(ctx, len(groups), func(ctx context.Context, i int) (string, error) {
+			return s.chat.Complete(ctx, CombinePrompt(video.Title, groups[i]))
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return s.chat.Complete(ctx, ReducePrompt(video.Title, partials, style))
+}
+
+// parallel runs n calls with at most Concurrency at once. The first error cancels other calls.
+func (s *LLMSummarizer) parallel(ctx context.Context, n int, call func(ctx context.Context, i int) (string, error)) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results  = make([]string, n)
+		slots    = make(chan struct{}, s.conf.Concurrency)
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	for i := 0; i < n; i++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			res, err := call(ctx, i)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+
+				return
+			}
+			results[i] = res
+		}(i)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Prompt returns chat messages which ask to summarize text in the style
+func Prompt(title, text string, style Style) []llm.Message {
+	instruction, ok := styleInstructions[style]
+	if !ok {
+		instruction = styleInstructions[Bullet]
+	}
+
+	return []llm.Message{
+		{
+			Role: llm.RoleSystem,
+			Content: "You summarize YouTube video transcriptions. " +
+				"Answer in the language of the transcript. " + instruction,
+		},
+		{
+			Role:    llm.RoleUser,
+			Content: fmt.Sprintf("Video title: %s\n\nTranscript:\n%s", title, text),
+		},
+	}
+}
+
+// ChunkPrompt asks to summarize a part of the transcript. Style is applied only by ReducePrompt.
+func ChunkPrompt(title, text string, part, parts int) []llm.Message {
+	return []llm.Message{
+		{
+			Role: llm.RoleSystem,
+			Content: "You summarize a part of a long YouTube video transcription. " +
+				"Answer in the language of the transcript. " +
+				"Write concise notes with all key ideas, facts and names in the order they appear.",
+		},
+		{
+			Role:    llm.RoleUser,
+			Content: fmt.Sprintf("Video title: %s\n\nTranscript part %d of %d:\n%s", title, part, parts, text),
+		},
+	}
+}
+
+// CombinePrompt asks to merge consecutive partial summaries into one
+func CombinePrompt(title string, partials []string) []llm.Message {
+	return []llm.Message{
+		{
+			Role: llm.RoleSystem,
+			Content: "You merge consecutive notes on parts of a YouTube video transcription. " +
+				"Answer in the language of the notes. " +
+				"Write concise notes keeping all key ideas in the order they appear and removing repetitions.",
+		},
+		{
+			Role:    llm.RoleUser,
+			Content: fmt.Sprintf("Video title: %s\n\nNotes:\n%s", title, strings.Join(partials, "\n\n")),
+		},
+	}
+}
+
+// ReducePrompt asks to write the final summary in the style from partial summaries
+func ReducePrompt(title string, partials []string, style Style) []llm.Message {
+	instruction, ok := styleInstructions[style]
+	if !ok {
+		instruction = styleInstructions[Bullet]
+	}
+
+	return []llm.Message{
+		{
+			Role: llm.RoleSystem,
+			Content: "You summarize YouTube video transcriptions from notes on its consecutive parts. " +
+				"Answer in the language of the notes. " + instruction,
+		},
+		{
+			Role:    llm.RoleUser,
+			Content: fmt.Sprintf("Video title: %s\n\nNotes:\n%s", title, strings.Join(partials, "\n\n")),
+		},
+	}
+}
+
+// Transcript joins segments subtitles into plain text
+func Transcript(transcription []models.Transcription) string {
+	parts := make([]string, 0, len(transcription))
+	for _, t := range transcription {
+		if s := strings.TrimSpace(t.Subtitle); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
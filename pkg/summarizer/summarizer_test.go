@@ -0,0 +1,69 @@
+package summarizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"transcribify/internal/models"
+	"transcribify/pkg/llm"
+)
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		style string
+		want  Style
+		err   error
+	}{
+		{style: "", want: Bullet},
+		{style: "paragraph", want: Paragraph},
+		{style: "tldr", want: TLDR},
+		{style: "poem", err: ErrUnknownStyle},
+	}
+
+	for _, test := range tests {
+		got, err := ParseStyle(test.style)
+		assert.ErrorIs(t, err, test.err)
+		assert.Equal(t, test.want, got)
+	}
+}
+
+func TestLLMSummarizer_Summarize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+		var req struct {
+			Model    string        `json:"model"`
+			Messages []llm.Message `json:"messages"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "stub", req.Model)
+		assert.Len(t, req.Messages, 2)
+		assert.Contains(t, req.Messages[1].Content, "hello world")
+
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"- greeting"}}]}`)
+	}))
+	defer server.Close()
+
+	s := New(llm.NewOpenAI(server.Client(), server.URL, "key", "stub"))
+	video := &models.YTVideo{
+		Title: "Title",
+		Transcription: []models.Transcription{
+			{Subtitle: "hello"},
+			{Subtitle: " "},
+			{Subtitle: "world"},
+		},
+	}
+
+	summary, err := s.Summarize(context.Background(), video, Bullet)
+	assert.NoError(t, err)
+	assert.Equal(t, "- greeting", summary)
+	assert.Equal(t, "stub", s.Model())
+
+	_, err = s.Summarize(context.Background(), &models.YTVideo{}, Bullet)
+	assert.ErrorIs(t, err, ErrEmptyTranscript)
+}
@@ -0,0 +1,33 @@
+package summarizer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenEstimator approximates the number of model tokens in the text.
+type TokenEstimator interface {
+	Estimate(text string) int
+}
+
+// TokenEstimatorFunc is an adapter to use ordinary functions as TokenEstimator
+type TokenEstimatorFunc func(text string) int
+
+func (f TokenEstimatorFunc) Estimate(text string) int {
+	return f(text)
+}
+
+// Heuristic estimates tokens without a tokenizer: roughly 4 characters or 3/4 of a word per token,
+// whichever is larger, so non-latin scripts and short words aren't underestimated.
+type Heuristic struct{}
+
+func (Heuristic) Estimate(text string) int {
+	chars := (utf8.RuneCountInString(text) + 3) / 4
+	words := (len(strings.Fields(text))*4 + 2) / 3
+
+	if words > chars {
+		return words
+	}
+
+	return chars
+}
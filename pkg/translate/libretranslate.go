@@ -0,0 +1,91 @@
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"transcribify/pkg/llm"
+)
+
+// LibreTranslate uses LibreTranslate compatible `/translate` API
+type LibreTranslate struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+func NewLibreTranslate(client *http.Client, baseURL, apiKey string) *LibreTranslate {
+	return &LibreTranslate{
+		client:  client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+	}
+}
+
+func (l *LibreTranslate) Name() string {
+	return "libretranslate"
+}
+
+type (
+	libreRequest struct {
+		Q      []string `json:"q"`
+		Source string   `json:"source"`
+		Target string   `json:"target"`
+		Format string   `json:"format"`
+		APIKey string   `json:"api_key,omitempty"` //nolint:tagliatelle
+	}
+
+	libreResponse struct {
+		TranslatedText []string `json:"translatedText"` //nolint:tagliatelle
+		Error          string   `json:"error"`
+	}
+)
+
+func (l *LibreTranslate) Translate(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	body, err := json.Marshal(libreRequest{Q: texts, Source: from, Target: to, Format: "text", APIKey: l.apiKey})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.baseURL+"/translate", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	response, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(response.Body, 16<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	var translation libreResponse
+	decodeErr := json.Unmarshal(raw, &translation)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		apiErr := &llm.APIError{Status: response.StatusCode, Message: string(raw)}
+		if decodeErr == nil && translation.Error != "" {
+			apiErr.Message = translation.Error
+		}
+
+		return nil, apiErr
+	}
+
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	if translation.Error != "" {
+		return nil, errors.New(translation.Error)
+	}
+
+	return translation.TranslatedText, nil
+}
@@ -0,0 +1,57 @@
+package translate
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"transcribify/internal/models"
+	"transcribify/pkg/language"
+	"transcribify/pkg/repository"
+)
+
+// Service translates videos and caches translations in repository.
+// It implements finders.Translator.
+type Service struct {
+	translator Translator
+	repo       repository.Translation
+	batch      int
+}
+
+func NewService(translator Translator, repository repository.Translation, batch int) *Service {
+	if batch < 1 {
+		batch = DefaultBatch
+	}
+
+	return &Service{
+		translator: translator,
+		repo:       repository,
+		batch:      batch,
+	}
+}
+
+// TranslateVideo returns cached translation of the source video or translates and stores it
+func (s *Service) TranslateVideo(ctx context.Context, source *models.YTVideo, from string, to models.VideoRequest) (*models.YTVideo, error) {
+	if language.Equal(from, to.Language) {
+		return nil, ErrSameLanguage
+	}
+
+	cached, err := s.repo.GetTranslation(ctx, to, from, s.translator.Name())
+	if err == nil {
+		return cached, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	video, err := Video(ctx, s.translator, source, from, to.Language, s.batch)
+	if err != nil {
+		return nil, err
+	}
+
+	video.Id, err = s.repo.PutTranslation(ctx, to, from, s.translator.Name(), video)
+	if err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
@@ -0,0 +1,100 @@
+package translate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"transcribify/internal/models"
+)
+
+// DefaultBatch is the number of segments translated by one request
+const DefaultBatch = 50
+
+var (
+	ErrNotConfigured = errors.New("translator is not configured")
+	ErrSameLanguage  = errors.New("source and target languages are the same")
+)
+
+// Translator translates texts from one language to another keeping their order
+type Translator interface {
+	Translate(ctx context.Context, texts []string, from, to string) ([]string, error)
+	// Name identifies translator of cached translations
+	Name() string
+}
+
+// Stub marks texts with the target language instead of translating them.
+// It is used for development without translation service.
+type Stub struct{}
+
+func (Stub) Name() string {
+	return "stub"
+}
+
+func (Stub) Translate(_ context.Context, texts []string, _, to string) ([]string, error) {
+	translated := make([]string, len(texts))
+	for i, text := range texts {
+		translated[i] = "[" + to + "] " + text
+	}
+
+	return translated, nil
+}
+
+// Video returns translated copy of the video. Title and description are translated with the first request,
+// segments are translated in batches keeping their Start and Dur. Chapters aren`t copied.
+func Video(ctx context.Context, translator Translator, video *models.YTVideo, from, to string, batch int) (*models.YTVideo, error) {
+	if batch < 1 {
+		batch = DefaultBatch
+	}
+
+	translated := &models.YTVideo{
+		AvailableLangs:    video.AvailableLangs,
+		LengthInSeconds:   video.LengthInSeconds,
+		Thumbnails:        video.Thumbnails,
+		Transcription:     make([]models.Transcription, len(video.Transcription)),
+		Provider:          video.Provider,
+		MachineTranslated: true,
+		TranslatedFrom:    from,
+	}
+
+	meta, err := translateBatch(ctx, translator, []string{video.Title, video.Description}, from, to)
+	if err != nil {
+		return nil, err
+	}
+	translated.Title, translated.Description = meta[0], meta[1]
+
+	for offset := 0; offset < len(video.Transcription); offset += batch {
+		end := offset + batch
+		if end > len(video.Transcription) {
+			end = len(video.Transcription)
+		}
+
+		texts := make([]string, 0, end-offset)
+		for _, segment := range video.Transcription[offset:end] {
+			texts = append(texts, segment.Subtitle)
+		}
+
+		subtitles, err := translateBatch(ctx, translator, texts, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("segments %d-%d: %w", offset, end-1, err)
+		}
+
+		for i, segment := range video.Transcription[offset:end] {
+			segment.Subtitle = subtitles[i]
+			translated.Transcription[offset+i] = segment
+		}
+	}
+
+	return translated, nil
+}
+
+func translateBatch(ctx context.Context, translator Translator, texts []string, from, to string) ([]string, error) {
+	translated, err := translator.Translate(ctx, texts, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(translated) != len(texts) {
+		return nil, fmt.Errorf("translator returned %d texts, expected %d", len(translated), len(texts))
+	}
+
+	return translated, nil
+}
@@ -0,0 +1,131 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"transcribify/internal/models"
+)
+
+type batchTranslator struct {
+	batches [][]string
+}
+
+func (b *batchTranslator) Name() string {
+	return "upper"
+}
+
+func (b *batchTranslator) Translate(_ context.Context, texts []string, _, _ string) ([]string, error) {
+	b.batches = append(b.batches, texts)
+
+	translated := make([]string, len(texts))
+	for i, text := range texts {
+		translated[i] = strings.ToUpper(text)
+	}
+
+	return translated, nil
+}
+
+func TestVideo(t *testing.T) {
+	video := &models.YTVideo{
+		Title:       "title",
+		Description: "description",
+		Transcription: []models.Transcription{
+			{Subtitle: "one", Start: 0, Dur: 1.5},
+			{Subtitle: "two", Start: 1.5, Dur: 2},
+			{Subtitle: "three", Start: 3.5, Dur: 1},
+		},
+		Chapters: []models.Chapter{{Title: "intro"}},
+	}
+	translator := &batchTranslator{}
+
+	translated, err := Video(context.Background(), translator, video, "en", "de", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"title", "description"}, {"one", "two"}, {"three"}}, translator.batches)
+	assert.Equal(t, "TITLE", translated.Title)
+	assert.Equal(t, []models.Transcription{
+		{Subtitle: "ONE", Start: 0, Dur: 1.5},
+		{Subtitle: "TWO", Start: 1.5, Dur: 2},
+		{Subtitle: "THREE", Start: 3.5, Dur: 1},
+	}, translated.Transcription)
+	assert.True(t, translated.MachineTranslated)
+	assert.Equal(t, "en", translated.TranslatedFrom)
+	assert.Nil(t, translated.Chapters)
+	assert.Equal(t, "one", video.Transcription[0].Subtitle)
+}
+
+func TestLibreTranslate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request libreRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+
+		if request.APIKey != "key" {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(libreResponse{Error: "invalid api key"})
+
+			return
+		}
+
+		translated := make([]string, len(request.Q))
+		for i, q := range request.Q {
+			translated[i] = request.Source + ">" + request.Target + ":" + q
+		}
+		_ = json.NewEncoder(w).Encode(libreResponse{TranslatedText: translated})
+	}))
+	defer server.Close()
+
+	translated, err := NewLibreTranslate(server.Client(), server.URL+"/", "key").
+		Translate(context.Background(), []string{"hello", "world"}, "en", "de")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"en>de:hello", "en>de:world"}, translated)
+
+	_, err = NewLibreTranslate(server.Client(), server.URL, "").
+		Translate(context.Background(), []string{"hello"}, "en", "de")
+	assert.ErrorContains(t, err, "invalid api key")
+}
+
+type memoryTranslations struct {
+	videos map[string]*models.YTVideo
+}
+
+func (m *memoryTranslations) GetTranslation(_ context.Context, request models.VideoRequest, from, translator string) (*models.YTVideo, error) {
+	video, ok := m.videos[request.VideoID+request.Language+from+translator]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+
+	return video, nil
+}
+
+func (m *memoryTranslations) PutTranslation(_ context.Context, request models.VideoRequest, from, translator string, video *models.YTVideo) (int, error) {
+	m.videos[request.VideoID+request.Language+from+translator] = video
+
+	return len(m.videos), nil
+}
+
+func TestServiceTranslateVideo(t *testing.T) {
+	var (
+		translator = &batchTranslator{}
+		service    = NewService(translator, &memoryTranslations{videos: map[string]*models.YTVideo{}}, 10)
+		source     = &models.YTVideo{Title: "title", Transcription: []models.Transcription{{Subtitle: "one"}}}
+		request    = models.VideoRequest{VideoID: "00000000001", Language: "de"}
+	)
+
+	translated, err := service.TranslateVideo(context.Background(), source, "en", request)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, translated.Id)
+
+	cached, err := service.TranslateVideo(context.Background(), source, "en", request)
+	assert.NoError(t, err)
+	assert.Same(t, translated, cached)
+	assert.Len(t, translator.batches, 2)
+
+	_, err = service.TranslateVideo(context.Background(), source, "de", request)
+	assert.ErrorIs(t, err, ErrSameLanguage)
+}
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var ErrForbiddenAddress = errors.New("webhook address is not allowed")
+
+// sharedAddressSpace is carrier-grade NAT range, it isn`t covered by net.IP IsPrivate
+var sharedAddressSpace = &net.IPNet{IP: net.IP{100, 64, 0, 0}, Mask: net.CIDRMask(10, 32)}
+
+// Guard rejects webhook addresses in loopback, link-local and private networks
+// unless they are in allowed networks
+type Guard struct {
+	allowed  []*net.IPNet
+	resolver *net.Resolver
+}
+
+func NewGuard(allowed []*net.IPNet) *Guard {
+	return &Guard{allowed: allowed, resolver: net.DefaultResolver}
+}
+
+// Allowed reports whether webhook can be delivered to ip
+func (g *Guard) Allowed(ip net.IP) bool {
+	for _, network := range g.allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip))
+}
+
+// CheckURL resolves host of webhook URL and returns ErrForbiddenAddress if any of its addresses isn`t allowed
+func (g *Guard) CheckURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		return g.check(ip)
+	}
+
+	addrs, err := g.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve webhook host: %w", err)
+	}
+
+	for _, addr := range addrs {
+		if err = g.check(addr.IP); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Client returns copy of client which connects only to allowed addresses.
+// Address is checked on dial, so DNS changes after registration and redirects are covered.
+// Proxy isn`t used, it would connect to the forbidden address instead of us.
+func (g *Guard) Client(client *http.Client) *http.Client {
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		transport = http.DefaultTransport.(*http.Transport)
+	}
+	transport = transport.Clone()
+	transport.Proxy = nil
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			return g.check(net.ParseIP(host))
+		},
+	}
+	transport.DialContext = dialer.DialContext
+
+	guarded := *client
+	guarded.Transport = transport
+
+	return &guarded
+}
+
+func (g *Guard) check(ip net.IP) error {
+	if ip == nil || !g.Allowed(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/cenkalti/backoff/v4"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+	"transcribify/pkg/repository"
+)
+
+const (
+	HeaderEvent     = "X-Transcribify-Event"
+	HeaderDelivery  = "X-Transcribify-Delivery"
+	HeaderSignature = "X-Transcribify-Signature"
+
+	// queueSize of deliveries waiting for worker
+	queueSize = 100
+)
+
+// Notifier sends event to user webhooks
+type Notifier interface {
+	Notify(ctx context.Context, uid int, event string, data any)
+}
+
+// Payload is the json body of delivery
+type Payload struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"createdAt"` //nolint:tagliatelle
+	Data      any       `json:"data"`
+}
+
+type task struct {
+	webhook  *models.Webhook
+	delivery *models.WebhookDelivery
+}
+
+// Dispatcher stores deliveries and sends them in background with exponential backoff retries
+// Deliveries are sent only to addresses allowed by Guard.
+type Dispatcher struct {
+	repo   repository.Webhook
+	client *http.Client
+	guard  *Guard
+	logger *zap.Logger
+	conf   config.WebhookConfiguration
+	queue  chan task
+	// queued deliveries ids, they aren`t enqueued again by sweep
+	queued sync.Map
+}
+
+func NewDispatcher(repo repository.Webhook, client *http.Client, logger *zap.Logger, conf config.WebhookConfiguration) *Dispatcher {
+	guard := NewGuard(conf.AllowedNetworks)
+
+	return &Dispatcher{
+		repo:   repo,
+		client: guard.Client(client),
+		guard:  guard,
+		logger: logger,
+		conf:   conf,
+		queue:  make(chan task, queueSize),
+	}
+}
+
+// CheckURL returns ErrForbiddenAddress if webhook URL resolves to the address deliveries can`t be sent to
+func (d *Dispatcher) CheckURL(ctx context.Context, url string) error {
+	return d.guard.CheckURL(ctx, url)
+}
+
+// Start runs delivery workers and sweep of stale pending deliveries until ctx is done
+func (d *Dispatcher) Start(ctx context.Context) {
+	for i := 0; i < d.conf.Workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case t := <-d.queue:
+					d.deliver(ctx, t.webhook, t.delivery)
+					d.queued.Delete(t.delivery.ID)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		ticker := time.NewTicker(d.conf.SweepInterval)
+		defer ticker.Stop()
+
+		for {
+			d.sweep(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// sweep enqueues pending deliveries which were dropped by full queue or shutdown
+func (d *Dispatcher) sweep(ctx context.Context) {
+	deliveries, err := d.repo.ClaimStaleDeliveries(ctx, d.conf.StaleAfter, queueSize)
+	if err != nil {
+		d.logger.Info("Failed to get stale deliveries", zap.Error(err))
+
+		return
+	}
+
+	for i := range deliveries {
+		webhook, err := d.repo.GetWebhook(ctx, deliveries[i].WebhookID)
+		if err != nil {
+			d.logger.Info("Failed to get webhook", zap.Error(err), zap.Int("webhook", deliveries[i].WebhookID))
+			continue
+		}
+
+		d.logger.Info("Enqueueing stale delivery", zap.Int("delivery", deliveries[i].ID))
+		if !d.enqueue(webhook, &deliveries[i]) {
+			return
+		}
+	}
+}
+
+// Notify creates delivery for every user webhook subscribed to event
+func (d *Dispatcher) Notify(ctx context.Context, uid int, event string, data any) {
+	webhooks, err := d.repo.GetWebhooksByEvent(ctx, uid, event)
+	if err != nil {
+		d.logger.Info("Failed to get webhooks", zap.Error(err), zap.Int("uid", uid))
+
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(Payload{Event: event, CreatedAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		d.logger.Info("Failed to encode webhook payload", zap.Error(err))
+
+		return
+	}
+
+	for i := range webhooks {
+		delivery := &models.WebhookDelivery{WebhookID: webhooks[i].ID, Event: event, Payload: payload}
+
+		if err = d.repo.CreateDelivery(ctx, delivery); err != nil {
+			d.logger.Info("Failed to create delivery", zap.Error(err), zap.Int("webhook", webhooks[i].ID))
+			continue
+		}
+
+		d.enqueue(&webhooks[i], delivery)
+	}
+}
+
+// Redeliver sends stored delivery again as the new delivery
+func (d *Dispatcher) Redeliver(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	redelivery := &models.WebhookDelivery{WebhookID: webhook.ID, Event: delivery.Event, Payload: delivery.Payload}
+
+	if err := d.repo.CreateDelivery(ctx, redelivery); err != nil {
+		return nil, err
+	}
+
+	d.enqueue(webhook, redelivery)
+
+	return redelivery, nil
+}
+
+// enqueue returns false if queue is full
+func (d *Dispatcher) enqueue(webhook *models.Webhook, delivery *models.WebhookDelivery) bool {
+	if _, queued := d.queued.LoadOrStore(delivery.ID, struct{}{}); queued {
+		return true
+	}
+
+	select {
+	case d.queue <- task{webhook: webhook, delivery: delivery}:
+		return true
+	default:
+		// delivery stays pending and is enqueued again by sweep
+		d.queued.Delete(delivery.ID)
+		d.logger.Info("Webhook queue is full", zap.Int("delivery", delivery.ID))
+
+		return false
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) {
+	policy := backoff.NewExponentialBackOff()
+	policy.InitialInterval = d.conf.InitialInterval
+	policy.MaxElapsedTime = d.conf.MaxElapsedTime
+
+	operation := func() error {
+		delivery.Attempts++
+
+		status, err := d.send(ctx, webhook, delivery)
+		delivery.ResponseStatus = status
+		delivery.Error = ""
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+
+		return err
+	}
+
+	err := backoff.RetryNotify(operation, backoff.WithContext(policy, ctx),
+		func(err error, duration time.Duration) {
+			d.logger.Info("Webhook delivery failed",
+				zap.Error(err),
+				zap.Int("delivery", delivery.ID),
+				zap.Duration("Waiting for", duration),
+			)
+			d.update(ctx, delivery)
+		})
+
+	delivery.State = models.DeliverySucceeded
+	if err != nil {
+		delivery.State = models.DeliveryFailed
+	}
+
+	d.update(ctx, delivery)
+}
+
+func (d *Dispatcher) update(ctx context.Context, delivery *models.WebhookDelivery) {
+	if err := d.repo.UpdateDelivery(ctx, delivery); err != nil {
+		d.logger.Info("Failed to update delivery", zap.Error(err), zap.Int("delivery", delivery.ID))
+	}
+}
+
+// send returns backoff.Permanent error for client errors which won`t be fixed by retry
+func (d *Dispatcher) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, backoff.Permanent(err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Transcribify-Webhook")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(delivery.ID))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, delivery.Payload))
+
+	response, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	switch code := response.StatusCode; {
+	case code >= 200 && code < 300:
+		return code, nil
+	case code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500:
+		return code, fmt.Errorf("unexpected status %s", response.Status)
+	default:
+		return code, backoff.Permanent(fmt.Errorf("unexpected status %s", response.Status))
+	}
+}
+
+// Sign returns `sha256=` prefixed hex HMAC-SHA256 of body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify compares signature in constant time
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// NewSecret returns random 32 bytes hex encoded secret
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
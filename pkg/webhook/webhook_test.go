@@ -0,0 +1,234 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"transcribify/internal/config"
+	"transcribify/internal/models"
+	"transcribify/pkg/repository"
+)
+
+type memoryWebhooks struct {
+	repository.Webhook
+
+	mu         sync.Mutex
+	webhooks   []models.Webhook
+	deliveries []*models.WebhookDelivery
+	stale      []models.WebhookDelivery
+	finished   chan models.WebhookDelivery
+}
+
+func (m *memoryWebhooks) GetWebhook(_ context.Context, id int) (*models.Webhook, error) {
+	for _, w := range m.webhooks {
+		if w.ID == id {
+			return &w, nil
+		}
+	}
+
+	return nil, errors.New("no rows in result set")
+}
+
+func (m *memoryWebhooks) ClaimStaleDeliveries(context.Context, time.Duration, int) ([]models.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stale := m.stale
+	m.stale = nil
+
+	return stale, nil
+}
+
+func (m *memoryWebhooks) GetWebhooksByEvent(_ context.Context, uid int, event string) ([]models.Webhook, error) {
+	res := make([]models.Webhook, 0)
+	for _, w := range m.webhooks {
+		for _, e := range w.Events {
+			if w.UserID == uid && e == event {
+				res = append(res, w)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (m *memoryWebhooks) CreateDelivery(_ context.Context, delivery *models.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delivery.ID = len(m.deliveries) + 1
+	delivery.State = models.DeliveryPending
+	m.deliveries = append(m.deliveries, delivery)
+
+	return nil
+}
+
+func (m *memoryWebhooks) UpdateDelivery(_ context.Context, delivery *models.WebhookDelivery) error {
+	if delivery.State != models.DeliveryPending {
+		m.finished <- *delivery
+	}
+
+	return nil
+}
+
+func TestDispatcher(t *testing.T) {
+	var (
+		calls  int32
+		secret = "0123456789abcdef"
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		assert.True(t, Verify(secret, body, r.Header.Get(HeaderSignature)))
+		assert.Equal(t, models.EventVideoCompleted, r.Header.Get(HeaderEvent))
+
+		var payload Payload
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, models.EventVideoCompleted, payload.Event)
+
+		// first attempt fails
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := &memoryWebhooks{
+		webhooks: []models.Webhook{
+			{ID: 1, UserID: 1, URL: server.URL, Secret: secret, Events: []string{models.EventVideoCompleted}},
+			{ID: 2, UserID: 1, URL: server.URL, Secret: secret, Events: []string{models.EventVideoFailed}},
+		},
+		finished: make(chan models.WebhookDelivery, 1),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dispatcher := NewDispatcher(repo, server.Client(), zap.NewNop(), config.WebhookConfiguration{
+		Workers:         1,
+		InitialInterval: time.Millisecond,
+		MaxElapsedTime:  time.Second,
+		SweepInterval:   time.Hour,
+		AllowedNetworks: []*net.IPNet{{IP: net.IPv4(127, 0, 0, 1), Mask: net.CIDRMask(32, 32)}},
+	})
+	dispatcher.Start(ctx)
+
+	dispatcher.Notify(ctx, 1, models.EventVideoCompleted, models.VideoEvent{VideoID: "00000000000", Language: "en"})
+
+	select {
+	case delivery := <-repo.finished:
+		assert.Equal(t, models.DeliverySucceeded, delivery.State)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Equal(t, http.StatusNoContent, delivery.ResponseStatus)
+		assert.Equal(t, 1, delivery.WebhookID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery is not finished")
+	}
+}
+
+func TestDispatcher_Sweep(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "7", r.Header.Get(HeaderDelivery))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	repo := &memoryWebhooks{
+		webhooks: []models.Webhook{
+			{ID: 1, UserID: 1, URL: server.URL, Secret: "0123456789abcdef", Events: []string{models.EventVideoCompleted}},
+		},
+		// delivery left pending by full queue or shutdown
+		stale: []models.WebhookDelivery{
+			{ID: 7, WebhookID: 1, Event: models.EventVideoCompleted, Payload: json.RawMessage(`{}`), State: models.DeliveryPending},
+		},
+		finished: make(chan models.WebhookDelivery, 1),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dispatcher := NewDispatcher(repo, server.Client(), zap.NewNop(), config.WebhookConfiguration{
+		Workers:         1,
+		InitialInterval: time.Millisecond,
+		MaxElapsedTime:  time.Second,
+		SweepInterval:   time.Millisecond,
+		StaleAfter:      time.Minute,
+		AllowedNetworks: []*net.IPNet{{IP: net.IPv4(127, 0, 0, 1), Mask: net.CIDRMask(32, 32)}},
+	})
+	dispatcher.Start(ctx)
+
+	select {
+	case delivery := <-repo.finished:
+		assert.Equal(t, 7, delivery.ID)
+		assert.Equal(t, models.DeliverySucceeded, delivery.State)
+		assert.Equal(t, 1, delivery.Attempts)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stale delivery is not sent")
+	}
+}
+
+func TestSign(t *testing.T) {
+	signature := Sign("secret", []byte(`{}`))
+
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.True(t, Verify("secret", []byte(`{}`), signature))
+	assert.False(t, Verify("other", []byte(`{}`), signature))
+}
+
+func TestGuard(t *testing.T) {
+	guard := NewGuard([]*net.IPNet{{IP: net.IPv4(10, 1, 0, 0), Mask: net.CIDRMask(16, 32)}})
+
+	tests := []struct {
+		name    string
+		url     string
+		allowed bool
+	}{
+		{name: "Public address", url: "https://93.184.216.34/hook", allowed: true},
+		{name: "Loopback", url: "http://127.0.0.1:8080/hook"},
+		{name: "IPv6 loopback", url: "http://[::1]/hook"},
+		{name: "Cloud metadata", url: "http://169.254.169.254/latest/meta-data"},
+		{name: "Private network", url: "http://192.168.1.10/hook"},
+		{name: "Shared address space", url: "http://100.64.0.1/hook"},
+		{name: "Unspecified", url: "http://0.0.0.0/hook"},
+		{name: "IPv4-mapped loopback", url: "http://[::ffff:127.0.0.1]/hook"},
+		{name: "Allowed private network", url: "http://10.1.2.3/hook", allowed: true},
+		{name: "Localhost", url: "http://localhost/hook"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.CheckURL(context.Background(), tt.url)
+			if tt.allowed {
+				assert.NoError(t, err)
+
+				return
+			}
+			assert.ErrorIs(t, err, ErrForbiddenAddress)
+		})
+	}
+}
+
+func TestGuard_Client(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewGuard(nil).Client(server.Client())
+
+	_, err := client.Get(server.URL)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+}
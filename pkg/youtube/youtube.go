@@ -0,0 +1,191 @@
+package youtube
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidURL  = errors.New("invalid YouTube URL")
+	ErrPlaylistURL = errors.New("playlist URL without video")
+	ErrChannelURL  = errors.New("channel URL without video")
+
+	videoID    = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	playlistID = regexp.MustCompile(`^[A-Za-z0-9_-]{12,64}$`)
+	startTime  = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?$`)
+
+	hosts = map[string]bool{
+		"youtube.com":              true,
+		"www.youtube.com":          true,
+		"m.youtube.com":            true,
+		"music.youtube.com":        true,
+		"youtube-nocookie.com":     true,
+		"www.youtube-nocookie.com": true,
+	}
+	shortHosts = map[string]bool{
+		"youtu.be":     true,
+		"www.youtu.be": true,
+	}
+	// pathPrefixes which are followed by video id
+	pathPrefixes = []string{"/shorts/", "/embed/", "/v/", "/live/", "/e/"}
+	// channelPrefixes of channel pages
+	channelPrefixes = []string{"/channel/", "/c/", "/user/", "/@"}
+)
+
+// Link is a normalized YouTube video link.
+type Link struct {
+	VideoID string
+	// Start is zero if link has no start time
+	Start time.Duration
+}
+
+// IsVideoID reports whether id is a canonical 11-char YouTube video id
+func IsVideoID(id string) bool {
+	return videoID.MatchString(id)
+}
+
+// Parse extracts canonical video id and start time from the video id or any form of YouTube video URL:
+// watch?v=, youtu.be/, /shorts/, /embed/, /live/ and m.youtube.com links.
+// Returns ErrPlaylistURL and ErrChannelURL for links without video.
+func Parse(raw string) (Link, error) {
+	raw = strings.TrimSpace(raw)
+	if IsVideoID(raw) {
+		return Link{VideoID: raw}, nil
+	}
+
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Link{}, fmt.Errorf("%w: %s", ErrInvalidURL, err)
+	}
+
+	var (
+		host  = strings.ToLower(u.Hostname())
+		query = u.Query()
+		id    string
+	)
+
+	switch {
+	case shortHosts[host]:
+		id = strings.Trim(u.Path, "/")
+	case hosts[host]:
+		id, err = idFromPath(u.Path, query)
+		if err != nil {
+			return Link{}, err
+		}
+	default:
+		return Link{}, fmt.Errorf("%w: unknown host %q", ErrInvalidURL, host)
+	}
+
+	if !IsVideoID(id) {
+		return Link{}, fmt.Errorf("%w: invalid video id %q", ErrInvalidURL, id)
+	}
+
+	link := Link{VideoID: id}
+
+	t := query.Get("t")
+	if t == "" {
+		t = query.Get("start")
+	}
+	if t == "" && strings.HasPrefix(u.Fragment, "t=") {
+		t = strings.TrimPrefix(u.Fragment, "t=")
+	}
+	if t != "" {
+		link.Start, err = ParseStart(t)
+		if err != nil {
+			return Link{}, err
+		}
+	}
+
+	return link, nil
+}
+
+func idFromPath(path string, query url.Values) (string, error) {
+	if path == "/watch" || path == "/watch/" {
+		if id := query.Get("v"); id != "" {
+			return id, nil
+		}
+		if query.Get("list") != "" {
+			return "", ErrPlaylistURL
+		}
+
+		return "", fmt.Errorf("%w: no video id", ErrInvalidURL)
+	}
+
+	if path == "/playlist" || path == "/playlist/" {
+		return "", ErrPlaylistURL
+	}
+
+	for _, prefix := range pathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return strings.SplitN(strings.TrimPrefix(path, prefix), "/", 2)[0], nil
+		}
+	}
+
+	for _, prefix := range channelPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return "", ErrChannelURL
+		}
+	}
+
+	return "", fmt.Errorf("%w: unsupported path %q", ErrInvalidURL, path)
+}
+
+// ParsePlaylist extracts playlist id from the playlist id or any YouTube URL with `list` parameter
+func ParsePlaylist(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if playlistID.MatchString(raw) {
+		return raw, nil
+	}
+
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidURL, err)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if !hosts[host] && !shortHosts[host] {
+		return "", fmt.Errorf("%w: unknown host %q", ErrInvalidURL, host)
+	}
+
+	id := u.Query().Get("list")
+	if !playlistID.MatchString(id) {
+		return "", fmt.Errorf("%w: invalid playlist id %q", ErrInvalidURL, id)
+	}
+
+	return id, nil
+}
+
+// ParseStart parses start time in `90`, `90s`, `1m30s` or `1h2m3s` forms
+func ParseStart(t string) (time.Duration, error) {
+	match := startTime.FindStringSubmatch(strings.ToLower(t))
+	if match == nil || t == "" {
+		return 0, fmt.Errorf("%w: invalid start time %q", ErrInvalidURL, t)
+	}
+
+	var start time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return 0, err
+		}
+		start += time.Duration(n) * unit
+	}
+
+	return start, nil
+}
@@ -0,0 +1,98 @@
+package youtube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected Link
+		err      error
+	}{
+		{name: "Video id", raw: "dQw4w9WgXcQ", expected: Link{VideoID: "dQw4w9WgXcQ"}},
+		{
+			name:     "Watch with start",
+			raw:      "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=30s",
+			expected: Link{VideoID: "dQw4w9WgXcQ", Start: 30 * time.Second},
+		},
+		{
+			name:     "Watch in playlist",
+			raw:      "https://www.youtube.com/watch?v=dQw4w9WgXcQ&list=PL590L5WQmH8fJ54F369BLDSqIwcs-TCfs",
+			expected: Link{VideoID: "dQw4w9WgXcQ"},
+		},
+		{
+			name:     "Short link without scheme",
+			raw:      "youtu.be/dQw4w9WgXcQ?t=1m5s",
+			expected: Link{VideoID: "dQw4w9WgXcQ", Start: 65 * time.Second},
+		},
+		{name: "Shorts", raw: "https://youtube.com/shorts/dQw4w9WgXcQ", expected: Link{VideoID: "dQw4w9WgXcQ"}},
+		{
+			name:     "Embed",
+			raw:      "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ?start=90",
+			expected: Link{VideoID: "dQw4w9WgXcQ", Start: 90 * time.Second},
+		},
+		{name: "Mobile", raw: "https://m.youtube.com/watch?v=dQw4w9WgXcQ", expected: Link{VideoID: "dQw4w9WgXcQ"}},
+		{
+			name:     "Fragment start",
+			raw:      "https://www.youtube.com/watch?v=dQw4w9WgXcQ#t=1h2m3s",
+			expected: Link{VideoID: "dQw4w9WgXcQ", Start: time.Hour + 2*time.Minute + 3*time.Second},
+		},
+		{name: "Playlist", raw: "https://www.youtube.com/playlist?list=PL590L5WQmH8fJ54F369BLDSqIwcs-TCfs", err: ErrPlaylistURL},
+		{name: "Watch playlist", raw: "https://www.youtube.com/watch?list=PL590L5WQmH8fJ54F369BLDSqIwcs-TCfs", err: ErrPlaylistURL},
+		{name: "Channel", raw: "https://www.youtube.com/channel/UCuAXFkgsw1L7xaCfnd5JJOw", err: ErrChannelURL},
+		{name: "Handle", raw: "https://www.youtube.com/@golang", err: ErrChannelURL},
+		{name: "Other host", raw: "https://vimeo.com/123456", err: ErrInvalidURL},
+		{name: "Invalid id", raw: "https://youtu.be/short", err: ErrInvalidURL},
+		{name: "Invalid start", raw: "https://youtu.be/dQw4w9WgXcQ?t=abc", err: ErrInvalidURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link, err := Parse(tt.raw)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, link)
+		})
+	}
+}
+
+func TestParsePlaylist(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+		err      error
+	}{
+		{name: "Playlist id", raw: "PL590L5WQmH8fJ54F369BLDSqIwcs-TCfs", expected: "PL590L5WQmH8fJ54F369BLDSqIwcs-TCfs"},
+		{
+			name:     "Playlist URL",
+			raw:      "https://www.youtube.com/playlist?list=PL590L5WQmH8fJ54F369BLDSqIwcs-TCfs",
+			expected: "PL590L5WQmH8fJ54F369BLDSqIwcs-TCfs",
+		},
+		{
+			name:     "Watch URL in playlist",
+			raw:      "youtube.com/watch?v=dQw4w9WgXcQ&list=PL590L5WQmH8fJ54F369BLDSqIwcs-TCfs",
+			expected: "PL590L5WQmH8fJ54F369BLDSqIwcs-TCfs",
+		},
+		{name: "Video URL", raw: "https://youtu.be/dQw4w9WgXcQ", err: ErrInvalidURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := ParsePlaylist(tt.raw)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}